@@ -1,5 +1,6 @@
 extends Node
 
+# Message types, defined by MessageType in Server/envelope.proto.
 enum  {
 	REQUEST_PLAYERS,
 	REGISTER,
@@ -10,11 +11,33 @@ enum  {
 	RESPAWN_PLAYER,
 	REQUEST_SCOREBOARD,
 	PLAYER_DISCONNECT,
+	CREATE_ROOM,
+	LIST_ROOMS,
+	JOIN_ROOM,
+	LEAVE_ROOM,
+	PROJECTILE_SPAWN,
+	PROJECTILE_HIT,
+	PROJECTILE_DESPAWN,
+	SNAPSHOT_ACK,
+	RELEVANCE_ENTER,
+	RELEVANCE_LEAVE,
+	INPUT,
+	POSITION_CORRECTION,
+	HELLO,
+	ERROR,
+	RESUME,
+	TIMEOUT_WARNING,
+	SELECT_SPELL,
+	MATCH_STATE,
+	MATCH_RESULTS,
+	SWITCH_TEAM,
 }
 
 
 var peer := WebSocketPeer.new()
 var local_player_id := 0
+# The last Resume message from the server, sent back with RESUME after reconnecting to keep the same player.
+var resume_token : PackedByteArray
 
 
 var scoreboard_data : ScoreboardProto.Scoreboard
@@ -26,9 +49,12 @@ signal player_registed(player: PlayerProto.Player)
 signal player_new_position(new_position : Vector3)
 
 signal puppet_fire_projectile(puppet: PlayerProto.Player)
+signal projectile_spawned(projectile : ProjectileProto.Projectile)
+signal projectile_removed(projectile : ProjectileProto.Projectile)
 signal puppet_new_position(puppet_id : int)
 signal new_puppet(player_data : PlayerProto.Player)
 signal player_disconnect(player_data : PlayerProto.Player)
+signal puppet_left(player_data : PlayerProto.Player)
 
 signal update_health(player_damage : PlayerProto.Player)
 signal update_scoreboard()
@@ -74,6 +100,12 @@ func register(message: PackedByteArray) -> bool:
 	socket.send(message)
 	return true
 
+func resume() -> bool:
+	if socket.get_ready_state() != WebSocketPeer.STATE_OPEN or resume_token.is_empty():
+		return false
+	send(RESUME, resume_token.duplicate())
+	return true
+
 func send(message_type : int, message: PackedByteArray) -> void:
 	message.insert(0 ,message_type)
 	socket.send(message)
@@ -110,6 +142,28 @@ func get_message() -> void:
 			handle_scoreboard(message_data)
 		PLAYER_DISCONNECT:
 			delete_puppet(message_data)
+		PROJECTILE_SPAWN:
+			spawn_projectile(message_data)
+		PROJECTILE_HIT, PROJECTILE_DESPAWN:
+			remove_projectile(message_data)
+		RELEVANCE_ENTER:
+			register_puppets(message_data)
+		RELEVANCE_LEAVE:
+			remove_puppets(message_data)
+		POSITION_CORRECTION:
+			correct_local_player(message_data)
+		HELLO:
+			handle_welcome(message_data)
+		ERROR:
+			handle_error(message_data)
+		RESUME:
+			resume_token = message_data
+		TIMEOUT_WARNING:
+			printerr("The server is about to disconnect this client for inactivity")
+		MATCH_STATE:
+			print("The match state changed")
+		MATCH_RESULTS:
+			print("The match is over")
 		_:
 			printerr("Undefined message type: ", message_type)
 			
@@ -121,6 +175,36 @@ func delete_puppet(message_data : PackedByteArray) -> void:
 		player_disconnect.emit(disconnected_player)
 	
 	
+func handle_welcome(message_data : PackedByteArray) -> void:
+	var welcome = EnvelopeProto.Welcome.new()
+	var result = welcome.from_bytes(message_data)
+	
+	if result != EnvelopeProto.PB_ERR.NO_ERRORS:
+		printerr("Unpacking failed.")
+	elif welcome.get_error() != "":
+		printerr("The server refused the connection: ", welcome.get_error())
+	else:
+		print("The server speaks protocol version ", welcome.get_protocol_version())
+
+func handle_error(message_data : PackedByteArray) -> void:
+	var error_message = ErrorProto.Error.new()
+	var result = error_message.from_bytes(message_data)
+	
+	if result == ErrorProto.PB_ERR.NO_ERRORS:
+		printerr("Server error ", ErrorProto.ErrorCode.find_key(error_message.get_code()), ": ", error_message.get_message())
+	else :
+		printerr("Unpacking failed.")
+
+func correct_local_player(message_data : PackedByteArray) -> void:
+	var corrected_player = PlayerProto.Player.new()
+	var result = corrected_player.from_bytes(message_data)
+	
+	if result == PlayerProto.PB_ERR.NO_ERRORS:
+		var new_position := corrected_player.get_pos()
+		player_new_position.emit(Vector3(new_position[0].get_x(), new_position[0].get_y(), new_position[0].get_z()))
+	else :
+		printerr("Unpacking failed.")
+
 func handle_scoreboard(message_data : PackedByteArray) -> void:
 	var new_score = ScoreboardProto.Scoreboard.new()
 	var result = new_score.from_bytes(message_data)
@@ -147,6 +231,24 @@ func set_puppet_cast(message_data : PackedByteArray) -> void:
 		printerr("Unpacking failed.")
 
 
+func spawn_projectile(message_data : PackedByteArray) -> void:
+	var new_projectile = ProjectileProto.Projectile.new()
+	var result = new_projectile.from_bytes(message_data)
+	
+	if result == ProjectileProto.PB_ERR.NO_ERRORS:
+		projectile_spawned.emit(new_projectile)
+	else :
+		printerr("Unpacking failed.")
+
+func remove_projectile(message_data : PackedByteArray) -> void:
+	var removed_projectile = ProjectileProto.Projectile.new()
+	var result = removed_projectile.from_bytes(message_data)
+	
+	if result == ProjectileProto.PB_ERR.NO_ERRORS:
+		projectile_removed.emit(removed_projectile)
+	else :
+		printerr("Unpacking failed.")
+
 func update_player_health(message_data : PackedByteArray) -> void:
 	var new_damage = PlayerProto.Player.new()
 	var result = new_damage.from_bytes(message_data)
@@ -167,6 +269,17 @@ func register_puppets(message_data : PackedByteArray) -> void:
 	else :
 		printerr("Unpacking failed.")
 
+func remove_puppets(message_data : PackedByteArray) -> void:
+	var left_puppet_data = PlayerProto.Players.new()
+	var result = left_puppet_data.from_bytes(message_data)
+	
+	if result == PlayerProto.PB_ERR.NO_ERRORS:
+		for puppet in left_puppet_data.get_player():
+			if puppet.get_id() != local_player_id:
+				puppet_left.emit(puppet)
+	else :
+		printerr("Unpacking failed.")
+
 func register_local_player(message_data : PackedByteArray) -> void:
 	var new_player_data = PlayerProto.Player.new()
 	var result = new_player_data.from_bytes(message_data)
@@ -1,16 +1,41 @@
 extends Node3D
 const PLAYER = preload("res://scenes/player.tscn")
+const PROJECTILE = preload("res://scenes/projectile.tscn")
 @onready var players: Node3D = $Players
 
+# Projectiles in flight by ID. The server simulates them and decides hits, they are only shown here.
+var projectiles := {}
+
 func _ready():
 	Client.connect("new_puppet", new_puppet)
+	Client.connect("projectile_spawned", spawn_projectile)
+	Client.connect("projectile_removed", remove_projectile)
 	var player_instance = PLAYER.instantiate()
 	players.add_child(player_instance)
 
 func new_puppet(puppet : PlayerProto.Player) -> void:
+	# A puppet listed by REQUEST_PLAYERS is announced again when it enters relevance.
+	for player in players.get_children():
+		if player.get_id() == puppet.get_id():
+			return
 	var puppet_instance = PLAYER.instantiate()
 	puppet_instance.local_player_data = puppet
 	puppet_instance.is_puppet = true
 	var new_position := puppet.get_pos()
 	puppet_instance.position = Vector3(new_position[0].get_x(), new_position[0].get_y(),new_position[0].get_z())
 	players.add_child(puppet_instance)
+
+func spawn_projectile(projectile : ProjectileProto.Projectile) -> void:
+	var projectile_instance = PROJECTILE.instantiate()
+	var new_position := projectile.get_position()
+	var new_velocity := projectile.get_velocity()
+	projectile_instance.position = Vector3(new_position.get_x(), new_position.get_y(), new_position.get_z())
+	projectile_instance.velocity = Vector3(new_velocity.get_x(), new_velocity.get_y(), new_velocity.get_z())
+	projectiles[projectile.get_id()] = projectile_instance
+	add_child(projectile_instance)
+
+func remove_projectile(projectile : ProjectileProto.Projectile) -> void:
+	var projectile_instance = projectiles.get(projectile.get_id())
+	projectiles.erase(projectile.get_id())
+	if is_instance_valid(projectile_instance):
+		projectile_instance.queue_free()
@@ -8,7 +8,6 @@ var local_player_data := PlayerProto.Player.new()
 @onready var camera_3d: Camera3D = $Camera3D
 @onready var mesh_instance_3d: MeshInstance3D = $MeshInstance3D
 @onready var input_emulator_timer: Timer = $InputEmulatorTimer
-const PROJECTILE = preload("res://scenes/projectile.tscn")
 var emulate_input := false
 
 var connection_tries := 0 
@@ -84,7 +83,7 @@ func _ready() -> void:
 	else:
 		init_puppet()
 		Client.connect("player_disconnect", player_disconnect)
-		Client.connect("puppet_fire_projectile", puppet_init_projectile)
+		Client.connect("puppet_left", player_disconnect)
 		Client.connect("puppet_new_position", puppet_new_position)
 
 func update_health (damage : PlayerProto.Player) -> void:
@@ -144,27 +143,10 @@ func get_id() -> int:
 	return local_player_data.get_id()
 
 func player_init_projectile() -> void:
-	
+	# The server launches the projectile and announces it with PROJECTILE_SPAWN.
 	var damage_package := PlayerProto.Damage.new()
 	damage_package.set_caster_id(local_player_data.get_id())
-	
 	Client.send(INIT_CAST, damage_package.to_bytes())
-	var projectile_instance = PROJECTILE.instantiate()
-	projectile_instance.position = global_transform.origin
-	projectile_instance.rotation.y = rotation.y
-	projectile_instance.set_meta("casted_by_puppet", false)
-	projectile_instance.set_meta("Owner", local_player_data.get_id())
-	get_tree().root.add_child(projectile_instance)
-
-func puppet_init_projectile(caster_id : int) -> void:
-	
-	if caster_id == local_player_data.get_id():
-		var projectile_instance = PROJECTILE.instantiate()
-		projectile_instance.position = global_transform.origin
-		projectile_instance.rotation.y = rotation.y
-		projectile_instance.set_meta("casted_by_puppet", true)
-		projectile_instance.set_meta("Owner", local_player_data.get_id())
-		get_tree().root.add_child(projectile_instance)
 
 func _physics_process(delta: float) -> void:
 	
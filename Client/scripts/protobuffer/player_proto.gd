@@ -717,6 +717,41 @@ class Player:
 		service.field = _player_state
 		data[_player_state.tag] = service
 		
+		_tick = PBField.new("tick", PB_DATA_TYPE.UINT64, PB_RULE.OPTIONAL, 11, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64])
+		service = PBServiceField.new()
+		service.field = _tick
+		data[_tick.tag] = service
+		
+		_last_input = PBField.new("last_input", PB_DATA_TYPE.UINT32, PB_RULE.OPTIONAL, 12, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _last_input
+		data[_last_input.tag] = service
+		
+		_mana = PBField.new("mana", PB_DATA_TYPE.FLOAT, PB_RULE.OPTIONAL, 13, false, DEFAULT_VALUES_2[PB_DATA_TYPE.FLOAT])
+		service = PBServiceField.new()
+		service.field = _mana
+		data[_mana.tag] = service
+		
+		_ready_tick = PBField.new("ready_tick", PB_DATA_TYPE.UINT64, PB_RULE.OPTIONAL, 14, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64])
+		service = PBServiceField.new()
+		service.field = _ready_tick
+		data[_ready_tick.tag] = service
+		
+		_respawn_tick = PBField.new("respawn_tick", PB_DATA_TYPE.UINT64, PB_RULE.OPTIONAL, 15, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64])
+		service = PBServiceField.new()
+		service.field = _respawn_tick
+		data[_respawn_tick.tag] = service
+		
+		_protected_tick = PBField.new("protected_tick", PB_DATA_TYPE.UINT64, PB_RULE.OPTIONAL, 16, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64])
+		service = PBServiceField.new()
+		service.field = _protected_tick
+		data[_protected_tick.tag] = service
+		
+		_team = PBField.new("team", PB_DATA_TYPE.UINT32, PB_RULE.OPTIONAL, 17, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _team
+		data[_team.tag] = service
+		
 	var data = {}
 	
 	var _name: PBField
@@ -811,6 +846,69 @@ class Player:
 	func set_player_state(value) -> void:
 		_player_state.value = value
 	
+	var _tick: PBField
+	func get_tick() -> int:
+		return _tick.value
+	func clear_tick() -> void:
+		data[11].state = PB_SERVICE_STATE.UNFILLED
+		_tick.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64]
+	func set_tick(value : int) -> void:
+		_tick.value = value
+	
+	var _last_input: PBField
+	func get_last_input() -> int:
+		return _last_input.value
+	func clear_last_input() -> void:
+		data[12].state = PB_SERVICE_STATE.UNFILLED
+		_last_input.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_last_input(value : int) -> void:
+		_last_input.value = value
+	
+	var _mana: PBField
+	func get_mana() -> float:
+		return _mana.value
+	func clear_mana() -> void:
+		data[13].state = PB_SERVICE_STATE.UNFILLED
+		_mana.value = DEFAULT_VALUES_2[PB_DATA_TYPE.FLOAT]
+	func set_mana(value : float) -> void:
+		_mana.value = value
+	
+	var _ready_tick: PBField
+	func get_ready_tick() -> int:
+		return _ready_tick.value
+	func clear_ready_tick() -> void:
+		data[14].state = PB_SERVICE_STATE.UNFILLED
+		_ready_tick.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64]
+	func set_ready_tick(value : int) -> void:
+		_ready_tick.value = value
+	
+	var _respawn_tick: PBField
+	func get_respawn_tick() -> int:
+		return _respawn_tick.value
+	func clear_respawn_tick() -> void:
+		data[15].state = PB_SERVICE_STATE.UNFILLED
+		_respawn_tick.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64]
+	func set_respawn_tick(value : int) -> void:
+		_respawn_tick.value = value
+	
+	var _protected_tick: PBField
+	func get_protected_tick() -> int:
+		return _protected_tick.value
+	func clear_protected_tick() -> void:
+		data[16].state = PB_SERVICE_STATE.UNFILLED
+		_protected_tick.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64]
+	func set_protected_tick(value : int) -> void:
+		_protected_tick.value = value
+	
+	var _team: PBField
+	func get_team() -> int:
+		return _team.value
+	func clear_team() -> void:
+		data[17].state = PB_SERVICE_STATE.UNFILLED
+		_team.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_team(value : int) -> void:
+		_team.value = value
+	
 	class Position:
 		func _init():
 			var service
@@ -973,7 +1071,8 @@ class Damage:
 enum PLAYER_STATE {
 	STANDING = 0,
 	CROUCHING = 1,
-	JUMPING = 2
+	JUMPING = 2,
+	DEAD = 3
 }
 
 class Players:
@@ -986,6 +1085,11 @@ class Players:
 		service.func_ref = Callable(self, "add_player")
 		data[_player.tag] = service
 		
+		_tick = PBField.new("tick", PB_DATA_TYPE.UINT64, PB_RULE.OPTIONAL, 2, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64])
+		service = PBServiceField.new()
+		service.field = _tick
+		data[_tick.tag] = service
+		
 	var data = {}
 	
 	var _player: PBField
@@ -999,6 +1103,15 @@ class Players:
 		_player.value.append(element)
 		return element
 	
+	var _tick: PBField
+	func get_tick() -> int:
+		return _tick.value
+	func clear_tick() -> void:
+		data[2].state = PB_SERVICE_STATE.UNFILLED
+		_tick.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64]
+	func set_tick(value : int) -> void:
+		_tick.value = value
+	
 	func _to_string() -> String:
 		return PBPacker.message_to_string(data)
 		
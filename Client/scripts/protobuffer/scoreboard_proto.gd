@@ -682,6 +682,16 @@ class Score:
 		service.field = _score
 		data[_score.tag] = service
 		
+		_rtt_ms = PBField.new("rtt_ms", PB_DATA_TYPE.UINT32, PB_RULE.OPTIONAL, 4, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _rtt_ms
+		data[_rtt_ms.tag] = service
+		
+		_team = PBField.new("team", PB_DATA_TYPE.UINT32, PB_RULE.OPTIONAL, 5, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _team
+		data[_team.tag] = service
+		
 	var data = {}
 	
 	var _name: PBField
@@ -711,6 +721,93 @@ class Score:
 	func set_score(value : int) -> void:
 		_score.value = value
 	
+	var _rtt_ms: PBField
+	func get_rtt_ms() -> int:
+		return _rtt_ms.value
+	func clear_rtt_ms() -> void:
+		data[4].state = PB_SERVICE_STATE.UNFILLED
+		_rtt_ms.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_rtt_ms(value : int) -> void:
+		_rtt_ms.value = value
+	
+	var _team: PBField
+	func get_team() -> int:
+		return _team.value
+	func clear_team() -> void:
+		data[5].state = PB_SERVICE_STATE.UNFILLED
+		_team.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_team(value : int) -> void:
+		_team.value = value
+	
+	func _to_string() -> String:
+		return PBPacker.message_to_string(data)
+		
+	func to_bytes() -> PackedByteArray:
+		return PBPacker.pack_message(data)
+		
+	func from_bytes(bytes : PackedByteArray, offset : int = 0, limit : int = -1) -> int:
+		var cur_limit = bytes.size()
+		if limit != -1:
+			cur_limit = limit
+		var result = PBPacker.unpack_message(data, bytes, offset, cur_limit)
+		if result == cur_limit:
+			if PBPacker.check_required(data):
+				if limit == -1:
+					return PB_ERR.NO_ERRORS
+			else:
+				return PB_ERR.REQUIRED_FIELDS
+		elif limit == -1 && result > 0:
+			return PB_ERR.PARSE_INCOMPLETE
+		return result
+	
+class TeamScore:
+	func _init():
+		var service
+		
+		_team = PBField.new("team", PB_DATA_TYPE.UINT32, PB_RULE.REQUIRED, 1, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _team
+		data[_team.tag] = service
+		
+		_score = PBField.new("score", PB_DATA_TYPE.UINT32, PB_RULE.REQUIRED, 2, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _score
+		data[_score.tag] = service
+		
+		_players = PBField.new("players", PB_DATA_TYPE.UINT32, PB_RULE.OPTIONAL, 3, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32])
+		service = PBServiceField.new()
+		service.field = _players
+		data[_players.tag] = service
+		
+	var data = {}
+	
+	var _team: PBField
+	func get_team() -> int:
+		return _team.value
+	func clear_team() -> void:
+		data[1].state = PB_SERVICE_STATE.UNFILLED
+		_team.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_team(value : int) -> void:
+		_team.value = value
+	
+	var _score: PBField
+	func get_score() -> int:
+		return _score.value
+	func clear_score() -> void:
+		data[2].state = PB_SERVICE_STATE.UNFILLED
+		_score.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_score(value : int) -> void:
+		_score.value = value
+	
+	var _players: PBField
+	func get_players() -> int:
+		return _players.value
+	func clear_players() -> void:
+		data[3].state = PB_SERVICE_STATE.UNFILLED
+		_players.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT32]
+	func set_players(value : int) -> void:
+		_players.value = value
+	
 	func _to_string() -> String:
 		return PBPacker.message_to_string(data)
 		
@@ -742,6 +839,17 @@ class Scoreboard:
 		service.func_ref = Callable(self, "add_score")
 		data[_score.tag] = service
 		
+		_tick = PBField.new("tick", PB_DATA_TYPE.UINT64, PB_RULE.OPTIONAL, 2, false, DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64])
+		service = PBServiceField.new()
+		service.field = _tick
+		data[_tick.tag] = service
+		
+		_team = PBField.new("team", PB_DATA_TYPE.MESSAGE, PB_RULE.REPEATED, 3, false, [])
+		service = PBServiceField.new()
+		service.field = _team
+		service.func_ref = Callable(self, "add_team")
+		data[_team.tag] = service
+		
 	var data = {}
 	
 	var _score: PBField
@@ -755,6 +863,26 @@ class Scoreboard:
 		_score.value.append(element)
 		return element
 	
+	var _tick: PBField
+	func get_tick() -> int:
+		return _tick.value
+	func clear_tick() -> void:
+		data[2].state = PB_SERVICE_STATE.UNFILLED
+		_tick.value = DEFAULT_VALUES_2[PB_DATA_TYPE.UINT64]
+	func set_tick(value : int) -> void:
+		_tick.value = value
+	
+	var _team: PBField
+	func get_team() -> Array:
+		return _team.value
+	func clear_team() -> void:
+		data[3].state = PB_SERVICE_STATE.UNFILLED
+		_team.value = []
+	func add_team() -> TeamScore:
+		var element = TeamScore.new()
+		_team.value.append(element)
+		return element
+	
 	func _to_string() -> String:
 		return PBPacker.message_to_string(data)
 		
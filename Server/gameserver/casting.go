@@ -0,0 +1,185 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// spellbook is the casting state of a player.
+type spellbook struct {
+	// ready holds the tick at which each spell the player has cast is off cooldown.
+	ready map[uint32]uint64
+	// spell is the spell being cast and finishes the tick its cast time is over, zero if the player is
+	// not casting.
+	spell    uint32
+	finishes uint64
+	// next is the tick from which the player may start another cast, see Config.MinCastInterval.
+	next uint64
+}
+
+// ticks converts a duration to simulation steps, rounding up.
+func (r *Room) ticks(d time.Duration) uint64 {
+	return uint64(math.Ceil(d.Seconds() * float64(r.config.TickRate)))
+}
+
+// spellbook returns the casting state of the player, creating it on first use. Callers must hold r.mu.
+func (r *Room) spellbook(playerID uint32) *spellbook {
+	book, ok := r.spellbooks[playerID]
+	if !ok {
+		book = &spellbook{ready: make(map[uint32]uint64)}
+		r.spellbooks[playerID] = book
+	}
+	return book
+}
+
+// Cast starts casting the player's current spell. Spells without a cast time are launched right away,
+// others set Player.casting until stepCasts launches them. It returns the INIT_CAST payload relayed to
+// the other clients and, if the spell was launched, the PROJECTILE_SPAWN payload. Casts from dead players
+// and casts that come too early, while the player is still casting, within Config.MinCastInterval of
+// their last cast or during the spell's cooldown, or without enough mana, are refused with a
+// CAST_REJECTED error. The early ones count towards GameServer.RejectedCasts.
+func (r *Room) Cast(playerID uint32) ([]byte, []byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.players.Load(playerID)
+	if !ok {
+		fmt.Printf("Rejecting cast from unregistered player %d\n", playerID)
+		return nil, nil, nil
+	}
+	caster := value.(*proto.Player)
+	if caster.GetPlayerState() == proto.PLAYER_STATE_DEAD || len(caster.GetPos()) == 0 {
+		// Not counted: a cast sent just before the player died is no sign of cheating.
+		return nil, nil, newError(proto.ErrorCode_CAST_REJECTED, "dead players cannot cast")
+	}
+
+	spellID := caster.GetCurrentSpell()
+	spell, ok := r.config.Spells[spellID]
+	if !ok {
+		fmt.Printf("Rejecting cast of unknown spell %d by player %d\n", spellID, playerID)
+		return nil, nil, ErrUnknownSpell
+	}
+	book := r.spellbook(playerID)
+	tick := r.Tick()
+	switch {
+	case book.finishes != 0:
+		return nil, nil, r.rejectCast(playerID, "already casting")
+	case tick < book.next:
+		return nil, nil, r.rejectCast(playerID, "casting faster than once per %v", r.config.MinCastInterval)
+	case tick < book.ready[spellID]:
+		return nil, nil, r.rejectCast(playerID, "spell %d is on cooldown until tick %d", spellID, book.ready[spellID])
+	case caster.GetMana() < spell.ManaCost:
+		return nil, nil, r.rejectCast(playerID, "spell %d needs %v mana", spellID, spell.ManaCost)
+	}
+
+	book.next = tick + r.ticks(r.config.MinCastInterval)
+	// Casting gives up spawn protection.
+	caster.ProtectedTick = nil
+	if spell.CastTime <= 0 {
+		return castData(playerID), r.launch(caster, book, spellID, spell), nil
+	}
+	book.spell = spellID
+	book.finishes = tick + r.ticks(spell.CastTime)
+	caster.Casting = proto2.Bool(true)
+	return castData(playerID), nil, nil
+}
+
+// rejectCast counts a cast that came too early and returns the CAST_REJECTED error for it. Callers must
+// hold r.mu.
+func (r *Room) rejectCast(playerID uint32, format string, args ...interface{}) *Error {
+	err := newError(proto.ErrorCode_CAST_REJECTED, format, args...)
+	rejected := 0
+	if c, ok := r.conns.Load(playerID); ok {
+		rejected = sessionOf(c.(*websocket.Conn)).recordRejectedCast()
+	}
+	fmt.Printf("Rejecting cast by player %d, %s (%d rejected casts)\n", playerID, err.Message, rejected)
+	return err
+}
+
+// RejectedCasts returns how many casts the player has sent too early or without enough mana, in any room.
+// Honest clients check casting, ready_tick and mana before casting, so a growing count hints at a
+// modified client.
+func (s *GameServer) RejectedCasts(playerID uint32) int {
+	return s.offensesOf(playerID).rejectedCasts
+}
+
+// launch ends the cast, takes its mana, starts its cooldown and spawns its projectile, returning the
+// PROJECTILE_SPAWN payload. Callers must hold r.mu.
+func (r *Room) launch(caster *proto.Player, book *spellbook, spellID uint32, spell Spell) []byte {
+	book.finishes = 0
+	book.ready[spellID] = r.Tick() + r.ticks(spell.Cooldown)
+	caster.Casting = proto2.Bool(false)
+	caster.Mana = proto2.Float32(caster.GetMana() - spell.ManaCost)
+	caster.ReadyTick = proto2.Uint64(book.ready[spellID])
+	return r.spawnProjectile(caster, spellID, spell)
+}
+
+// cancelCast stops the player's cast without launching it. Callers must hold r.mu.
+func (r *Room) cancelCast(p *proto.Player) {
+	if book, ok := r.spellbooks[p.GetId()]; ok {
+		book.finishes = 0
+	}
+	p.Casting = proto2.Bool(false)
+}
+
+// SelectSpell makes the spell the player's current spell, cancelling the cast in progress. Spells that
+// are not in Config.Spells are refused with ErrUnknownSpell.
+func (r *Room) SelectSpell(playerID, spellID uint32) error {
+	if _, ok := r.config.Spells[spellID]; !ok {
+		return ErrUnknownSpell
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.players.Load(playerID)
+	if !ok {
+		return nil
+	}
+	p := value.(*proto.Player)
+	if p.GetCurrentSpell() == spellID {
+		return nil
+	}
+	r.cancelCast(p)
+	p.CurrentSpell = proto2.Uint32(spellID)
+	p.ReadyTick = nil
+	if ready, ok := r.spellbook(playerID).ready[spellID]; ok {
+		p.ReadyTick = proto2.Uint64(ready)
+	}
+	return nil
+}
+
+// stepCasts regenerates every player's mana over dt and launches the casts whose cast time is over. Casts
+// of players that died or ran out of mana in the meantime are cancelled. It returns the PROJECTILE_SPAWN
+// events to broadcast. Callers must hold r.mu.
+func (r *Room) stepCasts(dt time.Duration) []roomEvent {
+	var events []roomEvent
+	tick := r.Tick()
+
+	r.players.Range(func(key, value interface{}) bool {
+		p := value.(*proto.Player)
+		if mana := p.GetMana(); mana < r.config.MaxMana {
+			p.Mana = proto2.Float32(min(r.config.MaxMana, mana+r.config.ManaRegen*float32(dt.Seconds())))
+		}
+
+		book, ok := r.spellbooks[key.(uint32)]
+		if !ok || book.finishes == 0 || tick < book.finishes {
+			return true
+		}
+		spell := r.config.Spells[book.spell]
+		if p.GetHealth() <= 0 || len(p.GetPos()) == 0 || p.GetMana() < spell.ManaCost {
+			r.cancelCast(p)
+			return true
+		}
+		if spawned := r.launch(p, book, book.spell, spell); spawned != nil {
+			events = append(events, roomEvent{PROJECTILE_SPAWN, spawned})
+		}
+		return true
+	})
+	return events
+}
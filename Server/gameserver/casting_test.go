@@ -0,0 +1,104 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"testing"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestLoadSpells(t *testing.T) {
+	spells, err := LoadSpells("../spells.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spells[0] != DefaultSpells()[0] {
+		t.Fatalf("spells.json has %+v as spell 0, want the built-in %+v", spells[0], DefaultSpells()[0])
+	}
+}
+
+func TestCastTime(t *testing.T) {
+	s := New(Config{TickRate: 10, Spells: map[uint32]Spell{
+		0: DefaultSpells()[0],
+		1: {Name: "Slow", Damage: 10, Speed: 5, Radius: 0.1, Lifetime: time.Second,
+			CastTime: 200 * time.Millisecond, Cooldown: time.Second, ManaCost: 30},
+	}})
+	room := s.defaultRoom
+	_, _, playerID := registerPlayer(t, s, "Caster")
+	value, _ := room.players.Load(playerID)
+	player := value.(*proto.Player)
+
+	if err := room.SelectSpell(playerID, 7); err != ErrUnknownSpell {
+		t.Fatalf("selecting an unknown spell returned %v", err)
+	}
+	if err := room.SelectSpell(playerID, 1); err != nil {
+		t.Fatal(err)
+	}
+	cast, spawned, err := room.Cast(playerID)
+	if err != nil || cast == nil || spawned != nil || !player.GetCasting() {
+		t.Fatal("casting a spell with a cast time did not start channeling it")
+	}
+
+	now := time.Now()
+	room.simulate(100*time.Millisecond, now)
+	if !player.GetCasting() || len(room.projectiles) != 0 {
+		t.Fatal("spell was launched before its cast time was over")
+	}
+	room.simulate(100*time.Millisecond, now.Add(100*time.Millisecond))
+	if player.GetCasting() || len(room.projectiles) != 1 {
+		t.Fatal("spell was not launched when its cast time was over")
+	}
+	if player.GetMana() != 70 || player.GetReadyTick() != room.Tick()+10 {
+		t.Fatalf("launch left %v mana and ready tick %d, want 70 and %d", player.GetMana(), player.GetReadyTick(), room.Tick()+10)
+	}
+
+	if _, _, err := room.Cast(playerID); err == nil {
+		t.Fatal("spell was cast again during its cooldown")
+	}
+	if rejected := s.RejectedCasts(playerID); rejected != 1 {
+		t.Fatalf("%d rejected casts counted, want 1", rejected)
+	}
+	for i := 0; i < 10; i++ {
+		room.simulate(100*time.Millisecond, now.Add(time.Duration(i+2)*100*time.Millisecond))
+	}
+	if cast, _, err := room.Cast(playerID); err != nil || cast == nil {
+		t.Fatalf("spell could not be cast after its cooldown: %v", err)
+	}
+	if _, _, err := room.Cast(playerID); err == nil || err.(*Error).Code != proto.ErrorCode_CAST_REJECTED {
+		t.Fatalf("casting while channeling returned %v", err)
+	}
+}
+
+func TestRejectedCastsFollowPlayer(t *testing.T) {
+	s := New(Config{})
+	c, _, playerID := registerPlayer(t, s, "Spammer")
+	cast := func() {
+		_, room := sessionOf(c).get()
+		room.Cast(playerID)
+	}
+
+	cast()
+	cast()
+	s.OnMessage(c, websocket.BinaryMessage, frame(CREATE_ROOM, &proto.Room{Name: proto2.String("Hideout")}))
+	if rejected := s.RejectedCasts(playerID); rejected != 1 {
+		t.Fatalf("%d rejected casts after switching rooms, want 1", rejected)
+	}
+
+	sess := sessionOf(c)
+	sess.mu.Lock()
+	token := sess.resumeToken
+	sess.mu.Unlock()
+	s.OnClose(c, nil)
+	if rejected := s.RejectedCasts(playerID); rejected != 1 {
+		t.Fatalf("%d rejected casts while disconnected, want 1", rejected)
+	}
+	c, _ = openTestConn(s)
+	s.OnMessage(c, websocket.BinaryMessage, frame(RESUME, &proto.Resume{Token: proto2.String(token)}))
+	cast()
+	cast()
+	if rejected := s.RejectedCasts(playerID); rejected != 2 {
+		t.Fatalf("%d rejected casts after resuming, want 2", rejected)
+	}
+}
@@ -0,0 +1,87 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// applyDamage subtracts damage from the target and credits casterID with a kill if the target died,
+// unless they are teammates.
+// Targets under spawn protection take no damage, and no one does after the match is over. It returns
+// the marshaled target, nil if it was not damaged, and whether the target died. Callers must hold r.mu.
+func (r *Room) applyDamage(casterID uint32, targetPlayer *proto.Player, damage float32) ([]byte, bool) {
+	if r.protected(targetPlayer) || r.match.phase == proto.MatchPhase_POST_GAME {
+		return nil, false
+	}
+
+	died := false
+	targetPlayer.Health = proto2.Float32(targetPlayer.GetHealth() - damage)
+	if targetPlayer.GetHealth() <= 0 {
+		died = true
+		r.kill(targetPlayer)
+		caster, found := r.players.Load(casterID)
+		// Team kills do not score.
+		teamKill := found && r.teammates(caster.(*proto.Player), targetPlayer)
+		if scoreValue, ok := r.scoreboard.Load(casterID); ok && !teamKill {
+			score := scoreValue.(*proto.Score)
+			score.Score = proto2.Uint32(score.GetScore() + 1)
+			r.scoreboard.Store(casterID, score)
+		}
+		if found && !teamKill {
+			r.creditTeam(caster.(*proto.Player))
+		}
+	}
+
+	byteSlice, protoErr := r.marshalPlayer(targetPlayer)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling damaged player with ID %d: %v\n", targetPlayer.GetId(), protoErr)
+		return nil, died
+	}
+	return byteSlice, died
+}
+
+// RespawnPlayer spawns a dead player again, see spawn, and returns the marshaled player. Callers must
+// hold r.mu.
+func (r *Room) RespawnPlayer(p *proto.Player) []byte {
+	r.spawn(p)
+	r.cancelCast(p)
+	r.resetHistory(p.GetId())
+	r.resetMovement(p.GetId())
+	r.resetLocationCheck(p.GetId(), positionVec(p.Pos[0]), time.Now())
+	byteSlice, protoErr := r.marshalPlayer(p)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling respawned player with ID %d: %v\n", p.GetId(), protoErr)
+		return nil
+	}
+	return byteSlice
+}
+
+// ReturnScoreboard marshals the current scoreboard.
+func (r *Room) ReturnScoreboard() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.marshalScoreboard()
+}
+
+// marshalScoreboard marshals the current scoreboard. Callers must hold r.mu.
+func (r *Room) marshalScoreboard() []byte {
+	scoreSlice := proto.Scoreboard{Tick: r.stamp(), Team: r.teamScores()}
+	r.scoreboard.Range(func(_, value interface{}) bool {
+		score := value.(*proto.Score)
+		score.RttMs = nil
+		if rtt, _, ok := r.Latency(score.GetId()); ok && rtt > 0 {
+			score.RttMs = proto2.Uint32(uint32(rtt.Milliseconds()))
+		}
+		scoreSlice.Score = append(scoreSlice.Score, score)
+		return true
+	})
+	byteSlice, protoErr := proto2.Marshal(&scoreSlice)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling Scoreboard: %v\n", protoErr)
+		return nil
+	}
+	return byteSlice
+}
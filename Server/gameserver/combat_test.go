@@ -0,0 +1,34 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"testing"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestDamagePlayer(t *testing.T) {
+	s := New(Config{})
+	room := s.defaultRoom
+	c, _, _ := registerPlayer(t, s, "Caster")
+	_, _, targetID := registerPlayer(t, s, "Target")
+	value, _ := room.players.Load(targetID)
+	target := value.(*proto.Player)
+	target.ProtectedTick = nil
+	target.Pos = []*proto.Player_Position{{X: proto2.Float32(1), Y: proto2.Float32(1), Z: proto2.Float32(0)}}
+	health := target.GetHealth()
+
+	// Hits are decided by the projectile simulation, so a client's report does no damage, even right after a cast.
+	report := frame(DAMAGE_PLAYER, &proto.Damage{
+		CasterId: proto2.Uint32(targetID),
+		TargetId: proto2.Uint32(targetID),
+		Damage:   proto2.Float32(1000),
+	})
+	s.OnMessage(c, websocket.BinaryMessage, report)
+	s.OnMessage(c, websocket.BinaryMessage, []byte{INIT_CAST})
+	s.OnMessage(c, websocket.BinaryMessage, report)
+	if target.GetHealth() != health {
+		t.Fatalf("target health is %v after forged reports, want %v", target.GetHealth(), health)
+	}
+}
@@ -0,0 +1,458 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"bytes"
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp"
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// conformanceTimeout bounds how long a test client waits for a frame.
+const conformanceTimeout = 2 * time.Second
+
+// testClient is a WebSocket client speaking protocol version 1, like the Godot client.
+type testClient struct {
+	t      *testing.T
+	conn   *websocket.Conn
+	frames chan []byte
+	// pending holds the frames received while waiting for another type.
+	pending [][]byte
+	// resumeToken is the token of the last RESUME frame taken with expectResume.
+	resumeToken string
+}
+
+// startTestServer starts a GameServer on a free local port and returns its address and the engine the
+// test clients dial with.
+func startTestServer(t *testing.T) (string, *nbhttp.Engine) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	s := New(Config{Addrs: []string{addr}, ResumeGracePeriod: 500 * time.Millisecond,
+		RespawnDelay: 200 * time.Millisecond, SpawnProtection: time.Millisecond, WarmupTime: time.Minute})
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	engine := nbhttp.NewEngine(nbhttp.Config{})
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		engine.Shutdown(ctx)
+		s.Shutdown(ctx)
+	})
+	return addr, engine
+}
+
+func dialTestClient(t *testing.T, engine *nbhttp.Engine, addr string) *testClient {
+	cl := &testClient{t: t, frames: make(chan []byte, 4096)}
+	upgrader := websocket.NewUpgrader()
+	upgrader.OnMessage(func(_ *websocket.Conn, _ websocket.MessageType, data []byte) {
+		cl.frames <- append([]byte(nil), data...)
+	})
+	dialer := &websocket.Dialer{Engine: engine, Upgrader: upgrader, DialTimeout: time.Second}
+	conn, _, err := dialer.Dial("ws://"+addr+"/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.conn = conn
+	return cl
+}
+
+func (cl *testClient) send(messageType byte, message proto2.Message) {
+	cl.t.Helper()
+	byteSlice := []byte{}
+	if message != nil {
+		var err error
+		byteSlice, err = proto2.Marshal(message)
+		if err != nil {
+			cl.t.Fatal(err)
+		}
+	}
+	if err := cl.conn.WriteMessage(websocket.BinaryMessage, append([]byte{messageType}, byteSlice...)); err != nil {
+		cl.t.Fatal(err)
+	}
+}
+
+// next returns the next frame of the given type that satisfies match, keeping the frames of other types
+// for later calls. Frames of the type that do not match are dropped.
+func (cl *testClient) next(messageType byte, match func(frame []byte) bool) []byte {
+	cl.t.Helper()
+	for len(cl.pending) > 0 {
+		i := 0
+		for i < len(cl.pending) && cl.pending[i][0] != messageType {
+			i++
+		}
+		if i == len(cl.pending) {
+			break
+		}
+		frame := cl.pending[i]
+		cl.pending = append(cl.pending[:i], cl.pending[i+1:]...)
+		if match == nil || match(frame) {
+			return frame
+		}
+	}
+
+	deadline := time.After(conformanceTimeout)
+	for {
+		select {
+		case frame := <-cl.frames:
+			if len(frame) == 0 {
+				cl.t.Fatalf("received an empty frame")
+			}
+			if frame[0] != messageType {
+				cl.pending = append(cl.pending, frame)
+				continue
+			}
+			if match == nil || match(frame) {
+				return frame
+			}
+		case <-deadline:
+			cl.t.Fatalf("no %s frame received", messageName(messageType))
+			return nil
+		}
+	}
+}
+
+// received moves the frames received so far to pending and reports whether any of them has the type.
+func (cl *testClient) received(messageType byte) bool {
+	for {
+		select {
+		case frame := <-cl.frames:
+			cl.pending = append(cl.pending, frame)
+		default:
+			for _, frame := range cl.pending {
+				if len(frame) > 0 && frame[0] == messageType {
+					return true
+				}
+			}
+			return false
+		}
+	}
+}
+
+// decode decodes the message of a frame.
+func decode(t *testing.T, frame []byte, message proto2.Message) {
+	t.Helper()
+	if err := proto2.Unmarshal(frame[1:], message); err != nil {
+		t.Fatalf("%s frame %v does not decode: %v", messageName(frame[0]), frame, err)
+	}
+}
+
+// assertFrame fails unless frame is exactly the type byte followed by the marshaled message.
+func assertFrame(t *testing.T, frame []byte, messageType byte, want proto2.Message) {
+	t.Helper()
+	byteSlice, err := proto2.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := append([]byte{messageType}, byteSlice...); !bytes.Equal(frame, expected) {
+		t.Fatalf("got %s frame\n%v\nwant\n%v", messageName(frame[0]), frame, expected)
+	}
+}
+
+// conformancePlayer is the Player the server sends for a player in the given state, with full mana and
+// no spell cooldown.
+func conformancePlayer(id uint32, name string, pos *proto.Player_Position, rotationY, health float32, tick *uint64) *proto.Player {
+	state := proto.PLAYER_STATE_STANDING
+	return &proto.Player{
+		Name:         proto2.String(name),
+		Id:           proto2.Uint32(id),
+		PlayerColor:  proto2.String("#ff0000"),
+		RotationY:    proto2.Float32(rotationY),
+		RotationX:    proto2.Float32(0),
+		Health:       proto2.Float32(health),
+		CurrentSpell: proto2.Uint32(0),
+		Casting:      proto2.Bool(false),
+		Pos:          []*proto.Player_Position{pos},
+		PlayerState:  &state,
+		Tick:         tick,
+		Mana:         proto2.Float32(DefaultConfig().MaxMana),
+	}
+}
+
+// register registers a player the way the Godot client does and checks the REGISTER reply.
+func (cl *testClient) register(name string) *proto.Player {
+	cl.t.Helper()
+	cl.send(REGISTER, testPlayer(name))
+
+	frame := cl.next(REGISTER, nil)
+	registered := &proto.Player{}
+	decode(cl.t, frame, registered)
+	if registered.GetId() == 0 || len(registered.GetPos()) != 1 || registered.Tick == nil ||
+		registered.GetProtectedTick() <= registered.GetTick() {
+		cl.t.Fatalf("REGISTER reply %v lacks an ID, position, tick or spawn protection", registered)
+	}
+	want := conformancePlayer(registered.GetId(), name, registered.GetPos()[0], 0, 100, registered.Tick)
+	want.ProtectedTick = registered.ProtectedTick
+	assertFrame(cl.t, frame, REGISTER, want)
+	// The test server's spawn protection is over by the next tick.
+	registered.ProtectedTick = nil
+	cl.expectResume()
+	return registered
+}
+
+// expectResume checks the RESUME frame that follows REGISTER and keeps its token.
+func (cl *testClient) expectResume() {
+	cl.t.Helper()
+	frame := cl.next(RESUME, nil)
+	resume := &proto.Resume{}
+	decode(cl.t, frame, resume)
+	if len(resume.GetToken()) != 32 || resume.GetToken() == cl.resumeToken {
+		cl.t.Fatalf("RESUME carries %q, want a new token", resume.GetToken())
+	}
+	assertFrame(cl.t, frame, RESUME, resume)
+	cl.resumeToken = resume.GetToken()
+}
+
+// assertPlayers fails unless frame is exactly a Players message of the type holding want, in the order
+// the frame lists them, stamped with the frame's tick.
+func assertPlayers(t *testing.T, frame []byte, messageType byte, want map[uint32]*proto.Player) {
+	t.Helper()
+	got := &proto.Players{}
+	decode(t, frame, got)
+	if len(got.GetPlayer()) != len(want) || got.Tick == nil {
+		t.Fatalf("%s carries %v, want %d players and a tick", messageName(messageType), got, len(want))
+	}
+	expected := &proto.Players{Tick: got.Tick}
+	for _, p := range got.GetPlayer() {
+		player, ok := want[p.GetId()]
+		if !ok {
+			t.Fatalf("%s carries unexpected player %v", messageName(messageType), p)
+		}
+		// Players registered in the last tick may still be protected.
+		player = proto2.Clone(player).(*proto.Player)
+		player.ProtectedTick = p.ProtectedTick
+		expected.Player = append(expected.Player, player)
+	}
+	assertFrame(t, frame, messageType, expected)
+}
+
+// assertScoreboard fails unless frame is exactly a scoreboard holding the scores, in the order the frame
+// lists them, stamped with the frame's tick.
+func assertScoreboard(t *testing.T, frame []byte, scores map[uint32]*proto.Score) {
+	t.Helper()
+	got := &proto.Scoreboard{}
+	decode(t, frame, got)
+	if len(got.GetScore()) != len(scores) || got.Tick == nil {
+		t.Fatalf("scoreboard %v, want %d scores and a tick", got, len(scores))
+	}
+	expected := &proto.Scoreboard{Tick: got.Tick}
+	for _, s := range got.GetScore() {
+		score, ok := scores[s.GetId()]
+		if !ok {
+			t.Fatalf("scoreboard carries unexpected score %v", s)
+		}
+		// The round trip time is measured, so any value is fine.
+		score = proto2.Clone(score).(*proto.Score)
+		score.RttMs = s.RttMs
+		expected.Score = append(expected.Score, score)
+	}
+	assertFrame(t, frame, REQUEST_SCOREBOARD, expected)
+}
+
+func score(p *proto.Player, kills uint32) *proto.Score {
+	return &proto.Score{Name: p.Name, Id: p.Id, Score: proto2.Uint32(kills)}
+}
+
+// TestConformance drives two protocol version 1 clients through a match, checking the exact bytes of
+// every frame the Godot client decodes.
+func TestConformance(t *testing.T) {
+	if raceEnabled {
+		t.Skip("nbio's poller is not clean under the race detector, TestConcurrentClients covers the server's own state")
+	}
+	addr, engine := startTestServer(t)
+
+	// Register: each client gets its player, everyone else the new player list, everyone the scoreboard.
+	alice := dialTestClient(t, engine, addr)
+	a := alice.register("Alice")
+	assertScoreboard(t, alice.next(REQUEST_SCOREBOARD, nil), map[uint32]*proto.Score{a.GetId(): score(a, 0)})
+
+	bob := dialTestClient(t, engine, addr)
+	b := bob.register("Bob")
+	a.Tick, b.Tick = nil, nil
+	assertPlayers(t, alice.next(REQUEST_PLAYERS, nil), REQUEST_PLAYERS, map[uint32]*proto.Player{a.GetId(): a, b.GetId(): b})
+	scores := map[uint32]*proto.Score{a.GetId(): score(a, 0), b.GetId(): score(b, 0)}
+	assertScoreboard(t, alice.next(REQUEST_SCOREBOARD, nil), scores)
+	assertScoreboard(t, bob.next(REQUEST_SCOREBOARD, nil), scores)
+
+	// Match: with two players the warmup starts, counting down to the match.
+	warmup := func(frame []byte) bool {
+		state := &proto.MatchState{}
+		decode(t, frame, state)
+		return state.GetPhase() == proto.MatchPhase_WARMUP
+	}
+	for _, cl := range []*testClient{alice, bob} {
+		frame := cl.next(MATCH_STATE, warmup)
+		state := &proto.MatchState{}
+		decode(t, frame, state)
+		if state.Tick == nil || state.GetEndsTick() <= state.GetTick() || state.GetSecondsLeft() != 60 {
+			t.Fatalf("MATCH_STATE carries %v, want the warmup to end in 60 seconds", state)
+		}
+		assertFrame(t, frame, MATCH_STATE, &proto.MatchState{Phase: state.Phase, EndsTick: state.EndsTick,
+			SecondsLeft: state.SecondsLeft, ScoreLimit: proto2.Uint32(uint32(DefaultConfig().ScoreLimit)),
+			Tick: state.Tick})
+	}
+
+	// Move: Alice walks up to Bob with UPDATE_LOCATION, which Bob sees in the snapshots.
+	from := positionVec(a.GetPos()[0])
+	target := positionVec(b.GetPos()[0])
+	offset := target.sub(from)
+	offset.y = 0
+	distance := offset.length()
+	direction := offset.scale(1 / distance)
+	rotationY := float32(math.Atan2(float64(-direction.x), float64(-direction.z)))
+	stop := from
+	if distance > 2 {
+		stop = target.sub(direction.scale(2))
+	}
+	// Walk at 6 u/s, within Config.MaxSpeed.
+	steps := int(math.Ceil(float64(stop.sub(from).length())/0.15)) + 1
+	path := stop.sub(from)
+	for i := 1; i <= steps; i++ {
+		stop = from.add(path.scale(float32(i) / float32(steps)))
+		alice.send(UPDATE_LOCATION, conformancePlayer(a.GetId(), "Alice", stop.position(), rotationY, 100, nil))
+		time.Sleep(25 * time.Millisecond)
+	}
+	if alice.received(POSITION_CORRECTION) {
+		t.Fatalf("Alice's walk was corrected")
+	}
+	a = conformancePlayer(a.GetId(), "Alice", stop.position(), rotationY, 100, nil)
+	aliceMoved := func(frame []byte) bool {
+		players := &proto.Players{}
+		decode(t, frame, players)
+		for _, p := range players.GetPlayer() {
+			if p.GetId() == a.GetId() && positionVec(p.GetPos()[0]) == stop {
+				return true
+			}
+		}
+		return false
+	}
+	assertPlayers(t, bob.next(UPDATE_LOCATION, aliceMoved), UPDATE_LOCATION, map[uint32]*proto.Player{a.GetId(): a, b.GetId(): b})
+
+	// Cast: every INIT_CAST spawns a projectile that hits Bob, once the spell's cooldown is over. The fourth
+	// one kills him.
+	health := float32(100)
+	var damagedTick uint64
+	for health > 0 {
+		time.Sleep(DefaultSpells()[0].Cooldown)
+		alice.send(INIT_CAST, nil)
+		assertFrame(t, bob.next(INIT_CAST, nil), INIT_CAST, &proto.Damage{CasterId: a.Id})
+		if health == 100 {
+			// Casting again right away is refused, and only the caster hears about it.
+			alice.send(INIT_CAST, nil)
+			if code, _ := errorCode(alice.next(ERROR, nil)); code != proto.ErrorCode_CAST_REJECTED {
+				t.Fatalf("early INIT_CAST was answered with %v, want CAST_REJECTED", code)
+			}
+		}
+
+		spawned := &proto.Projectile{}
+		frame := bob.next(PROJECTILE_SPAWN, nil)
+		decode(t, frame, spawned)
+		if spawned.GetCasterId() != a.GetId() || spawned.TargetId != nil {
+			t.Fatalf("PROJECTILE_SPAWN carries %v", spawned)
+		}
+		assertFrame(t, frame, PROJECTILE_SPAWN, spawned)
+
+		hit := &proto.Projectile{}
+		frame = bob.next(PROJECTILE_HIT, nil)
+		decode(t, frame, hit)
+		if hit.GetId() != spawned.GetId() || hit.GetTargetId() != b.GetId() {
+			t.Fatalf("PROJECTILE_HIT carries %v, want projectile %d hitting %d", hit, spawned.GetId(), b.GetId())
+		}
+		assertFrame(t, frame, PROJECTILE_HIT, hit)
+
+		health -= DefaultSpells()[0].Damage
+		damaged := &proto.Player{}
+		frame = alice.next(DAMAGE_PLAYER, nil)
+		decode(t, frame, damaged)
+		want := conformancePlayer(b.GetId(), "Bob", b.GetPos()[0], 0, health, damaged.Tick)
+		if health <= 0 {
+			// Bob stays where he fell until the respawn delay is over.
+			dead := proto.PLAYER_STATE_DEAD
+			want.PlayerState = &dead
+			want.RespawnTick = damaged.RespawnTick
+			damagedTick = damaged.GetTick()
+			if damaged.GetRespawnTick() <= damaged.GetTick() {
+				t.Fatalf("dead player %v has no respawn tick", damaged)
+			}
+		}
+		assertFrame(t, frame, DAMAGE_PLAYER, want)
+	}
+	scores[a.GetId()] = score(a, 1)
+	assertScoreboard(t, alice.next(REQUEST_SCOREBOARD, nil), scores)
+
+	// Respawn: once the respawn delay is over Bob comes back with full health at a spawn point, under
+	// spawn protection.
+	respawned := &proto.Player{}
+	frame := bob.next(RESPAWN_PLAYER, nil)
+	decode(t, frame, respawned)
+	if len(respawned.GetPos()) != 1 || respawned.GetTick() < damagedTick+uint64(DefaultConfig().TickRate)/5 ||
+		respawned.GetProtectedTick() <= respawned.GetTick() {
+		t.Fatalf("RESPAWN_PLAYER carries %v", respawned)
+	}
+	want := conformancePlayer(b.GetId(), "Bob", respawned.GetPos()[0], 0, 100, respawned.Tick)
+	want.ProtectedTick = respawned.ProtectedTick
+	assertFrame(t, frame, RESPAWN_PLAYER, want)
+
+	// Scoreboard: REQUEST_SCOREBOARD is answered with the current scores.
+	bob.send(REQUEST_SCOREBOARD, nil)
+	assertScoreboard(t, bob.next(REQUEST_SCOREBOARD, nil), scores)
+
+	// Resume: Alice's connection drops and she comes back on a new one with her token, as the same player
+	// with the same score. Bob is not told she was gone.
+	token := alice.resumeToken
+	alice.conn.Close()
+	alice = dialTestClient(t, engine, addr)
+	alice.resumeToken = token
+	alice.send(RESUME, &proto.Resume{Token: proto2.String(token)})
+	resumed := &proto.Player{}
+	frame = alice.next(REGISTER, nil)
+	decode(t, frame, resumed)
+	// Alice's mana and cooldown depend on the timing of her casts.
+	if resumed.GetMana() <= 0 || resumed.ReadyTick == nil {
+		t.Fatalf("resumed player %v did not keep the mana and cooldown of her casts", resumed)
+	}
+	a = conformancePlayer(a.GetId(), "Alice", stop.position(), rotationY, 100, nil)
+	a.Mana, a.ReadyTick = resumed.Mana, resumed.ReadyTick
+	a.Tick = resumed.Tick
+	assertFrame(t, frame, REGISTER, a)
+	alice.expectResume()
+	assertScoreboard(t, alice.next(REQUEST_SCOREBOARD, nil), scores)
+	if bob.received(PLAYER_DISCONNECT) {
+		t.Fatal("Bob was told Alice left while she was resuming")
+	}
+
+	// The old token is spent.
+	stranger := dialTestClient(t, engine, addr)
+	stranger.send(RESUME, &proto.Resume{Token: proto2.String(token)})
+	if code, _ := errorCode(stranger.next(ERROR, nil)); code != proto.ErrorCode_RESUME_FAILED {
+		t.Fatalf("resuming with a spent token failed with %v, want RESUME_FAILED", code)
+	}
+
+	// Disconnect: once the grace period is over Bob is told Alice left and gets the scoreboard without her.
+	alice.conn.Close()
+	disconnected := &proto.Player{}
+	frame = bob.next(PLAYER_DISCONNECT, nil)
+	decode(t, frame, disconnected)
+	a.Mana, a.Tick = disconnected.Mana, disconnected.Tick
+	assertFrame(t, frame, PLAYER_DISCONNECT, a)
+	delete(scores, a.GetId())
+	assertScoreboard(t, bob.next(REQUEST_SCOREBOARD, func(frame []byte) bool {
+		// Skip the scoreboards Bob received before Alice left.
+		got := &proto.Scoreboard{}
+		return proto2.Unmarshal(frame[1:], got) == nil && len(got.GetScore()) == len(scores)
+	}), scores)
+}
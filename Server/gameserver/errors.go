@@ -0,0 +1,60 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Error is a handler error that is reported to the sender in an ERROR frame.
+type Error struct {
+	Code    proto.ErrorCode
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(code proto.ErrorCode, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	// ErrNotRegistered is returned by RequireRegistration for messages from connections without a player.
+	ErrNotRegistered = &Error{Code: proto.ErrorCode_NOT_REGISTERED, Message: "connection has not registered"}
+	// ErrAlreadyRegistered is returned for REGISTER from a connection that already has a player.
+	ErrAlreadyRegistered = &Error{Code: proto.ErrorCode_ALREADY_REGISTERED, Message: "connection has already registered"}
+	// ErrRateLimited is returned by RateLimit for messages over the connection's limit.
+	ErrRateLimited = &Error{Code: proto.ErrorCode_RATE_LIMITED, Message: "rate limit exceeded"}
+	// ErrRoomFull is returned for REGISTER and JOIN_ROOM when the room has no free slot.
+	ErrRoomFull = &Error{Code: proto.ErrorCode_ROOM_FULL, Message: "room is full"}
+	// ErrResumeFailed is returned for RESUME with a token that is unknown or has expired.
+	ErrResumeFailed = &Error{Code: proto.ErrorCode_RESUME_FAILED, Message: "resume token is invalid or expired"}
+	// ErrUnknownSpell is returned for SELECT_SPELL with a spell that is not in Config.Spells.
+	ErrUnknownSpell = &Error{Code: proto.ErrorCode_UNKNOWN_SPELL, Message: "unknown spell"}
+)
+
+// sendError reports err to the connection. requestType is the type of the frame that failed, or -1 if
+// it had none.
+func sendError(c *websocket.Conn, requestType int, err *Error) {
+	message := &proto.Error{
+		Code:    err.Code.Enum(),
+		Message: proto2.String(err.Message),
+	}
+	if requestType >= 0 {
+		message.RequestType = proto2.Uint32(uint32(requestType))
+	}
+	byteSlice, protoErr := proto2.Marshal(message)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling Error: %v\n", protoErr)
+		return
+	}
+	writeErr := writeFrame(c, ERROR, byteSlice)
+	if writeErr != nil {
+		fmt.Println("ERROR error")
+		fmt.Println(writeErr.Error())
+	}
+}
@@ -0,0 +1,134 @@
+package gameserver
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	"google.golang.org/protobuf/encoding/protowire"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// maxPooledFrame is the largest buffer returned to framePool, so one huge snapshot does not pin its
+// memory forever.
+const maxPooledFrame = 1 << 20
+
+// Field numbers of the Players message in player_data.proto.
+const (
+	playersPlayerField protowire.Number = 1
+	playersTickField   protowire.Number = 2
+)
+
+var framePool = sync.Pool{
+	New: func() interface{} {
+		return &sharedFrame{data: make([]byte, 0, 1024)}
+	},
+}
+
+// sharedFrame is an outbound frame built once and queued on every connection it is sent to. Its buffer
+// comes from framePool and goes back when the last reference is released, so the frame must not be
+// modified once it has been sent.
+type sharedFrame struct {
+	data []byte
+	refs atomic.Int32
+}
+
+// newFrame returns a pooled frame holding just the message type, with one reference owned by the caller.
+func newFrame(messageType byte) *sharedFrame {
+	f := framePool.Get().(*sharedFrame)
+	f.data = append(f.data[:0], messageType)
+	f.refs.Store(1)
+	return f
+}
+
+// marshalFrame returns a pooled frame holding the message type followed by the marshaled message.
+func marshalFrame(messageType byte, message proto2.Message) (*sharedFrame, error) {
+	f := newFrame(messageType)
+	byteSlice, protoErr := proto2.MarshalOptions{}.MarshalAppend(f.data, message)
+	if protoErr != nil {
+		f.release()
+		return nil, protoErr
+	}
+	f.data = byteSlice
+	return f, nil
+}
+
+func (f *sharedFrame) retain() {
+	f.refs.Add(1)
+}
+
+func (f *sharedFrame) release() {
+	if f.refs.Add(-1) != 0 {
+		return
+	}
+	if cap(f.data) <= maxPooledFrame {
+		framePool.Put(f)
+	}
+}
+
+// sendFrame queues a frame on the connection, see writeFrame. The connection takes its own reference.
+func sendFrame(c *websocket.Conn, f *sharedFrame) error {
+	sess := sessionOf(c)
+	f.retain()
+	err := sess.out.push(outboundFrame{data: f.data, shared: f, envelope: sess.protocolVersion() >= envelopeProtocol}, time.Now())
+	if err != nil {
+		f.release()
+	}
+	return err
+}
+
+// playerEntries holds the Players.player entries of every player in a world, each marshaled once, so
+// the Players messages of any subset of the world are assembled by concatenating entries.
+type playerEntries struct {
+	tick    uint64
+	data    []byte
+	offsets map[uint32][2]int
+}
+
+func newPlayerEntries(world worldState, tick uint64) (*playerEntries, error) {
+	e := &playerEntries{tick: tick, offsets: make(map[uint32][2]int, len(world))}
+	for id, ps := range world {
+		player := ps.player(id)
+		start := len(e.data)
+		e.data = protowire.AppendTag(e.data, playersPlayerField, protowire.BytesType)
+		e.data = protowire.AppendVarint(e.data, uint64(proto2.Size(player)))
+		byteSlice, protoErr := proto2.MarshalOptions{UseCachedSize: true}.MarshalAppend(e.data, player)
+		if protoErr != nil {
+			return nil, protoErr
+		}
+		e.data = byteSlice
+		e.offsets[id] = [2]int{start, len(e.data)}
+	}
+	return e, nil
+}
+
+// frame returns a pooled frame of the given type with the Players message of the listed players,
+// stamped with the tick. Players missing from the world are skipped.
+func (e *playerEntries) frame(messageType byte, ids []uint32) *sharedFrame {
+	f := newFrame(messageType)
+	for _, id := range ids {
+		if offset, ok := e.offsets[id]; ok {
+			f.data = append(f.data, e.data[offset[0]:offset[1]]...)
+		}
+	}
+	f.data = protowire.AppendTag(f.data, playersTickField, protowire.VarintType)
+	f.data = protowire.AppendVarint(f.data, e.tick)
+	return f
+}
+
+// broadcastFrame queues the frame on every connection in the room except the one owned by player id,
+// or on all of them if id is zero.
+func (r *Room) broadcastFrame(f *sharedFrame, id uint32) {
+	r.conns.Range(func(key, value interface{}) bool {
+		if key.(uint32) == id {
+			return true
+		}
+		err := sendFrame(value.(*websocket.Conn), f)
+		if err != nil {
+			fmt.Println("Failed to send message to client:", err)
+		}
+		return true
+	})
+}
@@ -0,0 +1,127 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"math"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Player hitbox dimensions, matching the default CapsuleShape3D of player.tscn.
+const (
+	playerRadius       = 0.5
+	playerHeight       = 2.0
+	playerCrouchHeight = 1.2
+)
+
+// arenaHalfSize is half the width of the square arena centered on the origin.
+const arenaHalfSize = 9
+
+type vec3 struct {
+	x, y, z float32
+}
+
+func positionVec(p *proto.Player_Position) vec3 {
+	return vec3{p.GetX(), p.GetY(), p.GetZ()}
+}
+
+func (v vec3) position() *proto.Player_Position {
+	return &proto.Player_Position{X: proto2.Float32(v.x), Y: proto2.Float32(v.y), Z: proto2.Float32(v.z)}
+}
+
+func (v vec3) add(o vec3) vec3 {
+	return vec3{v.x + o.x, v.y + o.y, v.z + o.z}
+}
+
+func (v vec3) sub(o vec3) vec3 {
+	return vec3{v.x - o.x, v.y - o.y, v.z - o.z}
+}
+
+func (v vec3) scale(f float32) vec3 {
+	return vec3{v.x * f, v.y * f, v.z * f}
+}
+
+func (v vec3) dot(o vec3) float32 {
+	return v.x*o.x + v.y*o.y + v.z*o.z
+}
+
+func (v vec3) length() float32 {
+	return float32(math.Sqrt(float64(v.dot(v))))
+}
+
+// forward returns the horizontal direction a node faces in Godot for the given rotation around the Y axis.
+func forward(rotationY float32) vec3 {
+	sin, cos := math.Sincos(float64(rotationY))
+	return vec3{-float32(sin), 0, -float32(cos)}
+}
+
+// playerCapsule returns the end points of the capsule axis and the radius of a player's hitbox.
+func playerCapsule(p *proto.Player) (vec3, vec3, float32) {
+	return capsule(positionVec(p.GetPos()[0]), p.GetPlayerState())
+}
+
+// capsule returns the hitbox of a player at center in the given state. center is the center of a
+// standing capsule; crouching players keep their feet in place.
+func capsule(center vec3, state proto.PLAYER_STATE) (vec3, vec3, float32) {
+	feet := center.y - playerHeight/2
+
+	height := float32(playerHeight)
+	if state == proto.PLAYER_STATE_CROUCHING {
+		height = playerCrouchHeight
+	}
+
+	bottom := vec3{center.x, feet + playerRadius, center.z}
+	top := vec3{center.x, feet + height - playerRadius, center.z}
+	return bottom, top, playerRadius
+}
+
+func clamp01(f float32) float32 {
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// segmentDistance returns the closest distance between the segments p1-q1 and p2-q2.
+func segmentDistance(p1, q1, p2, q2 vec3) float32 {
+	d1 := q1.sub(p1)
+	d2 := q2.sub(p2)
+	r := p1.sub(p2)
+	a := d1.dot(d1)
+	e := d2.dot(d2)
+	f := d2.dot(r)
+
+	var s, t float32
+	switch {
+	case a <= 1e-6 && e <= 1e-6:
+		return r.length()
+	case a <= 1e-6:
+		t = clamp01(f / e)
+	default:
+		c := d1.dot(r)
+		if e <= 1e-6 {
+			s = clamp01(-c / a)
+		} else {
+			b := d1.dot(d2)
+			denom := a*e - b*b
+			if denom != 0 {
+				s = clamp01((b*f - c*e) / denom)
+			}
+			t = (b*s + f) / e
+			if t < 0 {
+				t = 0
+				s = clamp01(-c / a)
+			} else if t > 1 {
+				t = 1
+				s = clamp01((b - c) / a)
+			}
+		}
+	}
+
+	c1 := p1.add(d1.scale(s))
+	c2 := p2.add(d2.scale(t))
+	return c1.sub(c2).length()
+}
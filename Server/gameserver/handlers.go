@@ -0,0 +1,161 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Handle registers the handler for a message type, see Router.Handle. Built-in message types can be
+// overridden the same way.
+func (s *GameServer) Handle(messageType byte, message proto2.Message, handler HandlerFunc) {
+	s.router.Handle(messageType, message, handler)
+}
+
+// Use wraps every handler in the given middleware, see Router.Use.
+func (s *GameServer) Use(middleware ...Middleware) {
+	s.router.Use(middleware...)
+}
+
+// registerHandlers registers the handlers of the built-in message types.
+func (s *GameServer) registerHandlers() {
+	s.Handle(HELLO, nil, s.handleHello)
+	s.Handle(REQUEST_PLAYERS, nil, handleRequestPlayers)
+	s.Handle(REGISTER, &proto.Player{}, s.handleRegister)
+	s.Handle(RESUME, &proto.Resume{}, s.handleResume)
+	s.Handle(UPDATE_LOCATION, &proto.Player{}, handleUpdateLocation)
+	s.Handle(INPUT, &proto.Input{}, handleInput)
+	s.Handle(POLL_LOCATIONS, nil, handlePollLocations)
+	s.Handle(DAMAGE_PLAYER, nil, handleDamagePlayer)
+	s.Handle(INIT_CAST, nil, handleInitCast)
+	s.Handle(SELECT_SPELL, &proto.SpellSelection{}, handleSelectSpell)
+	s.Handle(SWITCH_TEAM, &proto.TeamSwitch{}, handleSwitchTeam)
+	s.Handle(REQUEST_SCOREBOARD, nil, handleRequestScoreboard)
+	s.Handle(SNAPSHOT_ACK, &proto.SnapshotAck{}, handleSnapshotAck)
+	s.Handle(CREATE_ROOM, &proto.Room{}, s.handleCreateRoom)
+	s.Handle(LIST_ROOMS, nil, s.handleListRooms)
+	s.Handle(JOIN_ROOM, &proto.Room{}, s.handleJoinRoom)
+	s.Handle(LEAVE_ROOM, nil, s.handleLeaveRoom)
+}
+
+func (s *GameServer) handleHello(ctx *Context) error {
+	s.hello(ctx.Data, ctx.Conn)
+	return nil
+}
+
+func handleRequestPlayers(ctx *Context) error {
+	return writeFrame(ctx.Conn, REQUEST_PLAYERS, ctx.Room.PollPlayers())
+}
+
+func (s *GameServer) handleRegister(ctx *Context) error {
+	if ctx.PlayerID != 0 {
+		return ErrAlreadyRegistered
+	}
+
+	room := ctx.Room
+	registered, err := room.RegisterPlayer(ctx.Message.(*proto.Player), ctx.Conn)
+	if err != nil {
+		return err
+	}
+	err = writeFrame(ctx.Conn, REGISTER, registered)
+	playerID, _ := sessionOf(ctx.Conn).get()
+	s.issueResumeToken(ctx.Conn, playerID)
+	room.BroadcastPlayerData(REQUEST_PLAYERS, room.PollPlayers(), playerID)
+	room.BroadcastMessage(REQUEST_SCOREBOARD, room.ReturnScoreboard())
+	if writeErr := writeFrame(ctx.Conn, MATCH_STATE, room.MatchState()); err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+func (s *GameServer) handleResume(ctx *Context) error {
+	return s.resume(ctx.Conn, ctx.Message.(*proto.Resume).GetToken())
+}
+
+func handleUpdateLocation(ctx *Context) error {
+	correction, kick := ctx.Room.UpdatePlayerLocation(ctx.PlayerID, ctx.Message.(*proto.Player))
+	if kick {
+		fmt.Printf("Kicking player %d for too many movement violations\n", ctx.PlayerID)
+		sessionOf(ctx.Conn).kick()
+		defer ctx.Conn.Close()
+	}
+	if correction != nil {
+		return writeFrame(ctx.Conn, POSITION_CORRECTION, correction)
+	}
+	return nil
+}
+
+func handleInput(ctx *Context) error {
+	if ctx.Room.QueueInput(ctx.PlayerID, ctx.Message.(*proto.Input)) {
+		fmt.Printf("Kicking player %d for too many movement violations\n", ctx.PlayerID)
+		sessionOf(ctx.Conn).kick()
+		ctx.Conn.Close()
+	}
+	return nil
+}
+
+func handlePollLocations(ctx *Context) error {
+	return writeFrame(ctx.Conn, REQUEST_PLAYERS, ctx.Room.PollPlayerLocations())
+}
+
+// handleDamagePlayer ignores the frame: hits are decided by the projectile simulation, client hit
+// reports are ignored.
+func handleDamagePlayer(ctx *Context) error {
+	return nil
+}
+
+func handleInitCast(ctx *Context) error {
+	room := ctx.Room
+	cast, spawned, err := room.Cast(ctx.PlayerID)
+	if err != nil {
+		return err
+	}
+	if spawned != nil {
+		room.BroadcastMessage(PROJECTILE_SPAWN, spawned)
+	}
+	if cast != nil {
+		room.BroadcastPlayerData(INIT_CAST, cast, ctx.PlayerID)
+	}
+	return nil
+}
+
+func handleSelectSpell(ctx *Context) error {
+	return ctx.Room.SelectSpell(ctx.PlayerID, ctx.Message.(*proto.SpellSelection).GetSpell())
+}
+
+func handleSwitchTeam(ctx *Context) error {
+	room := ctx.Room
+	switched, err := room.SwitchTeam(ctx.PlayerID, ctx.Message.(*proto.TeamSwitch).GetTeam())
+	if err != nil || switched == nil {
+		return err
+	}
+	room.BroadcastMessage(DAMAGE_PLAYER, switched)
+	room.BroadcastMessage(REQUEST_SCOREBOARD, room.ReturnScoreboard())
+	return nil
+}
+
+func handleRequestScoreboard(ctx *Context) error {
+	return writeFrame(ctx.Conn, REQUEST_SCOREBOARD, ctx.Room.ReturnScoreboard())
+}
+
+func handleSnapshotAck(ctx *Context) error {
+	sessionOf(ctx.Conn).ackSnapshot(ctx.Message.(*proto.SnapshotAck).GetTick())
+	return nil
+}
+
+func (s *GameServer) handleCreateRoom(ctx *Context) error {
+	return s.createRoom(ctx.Message.(*proto.Room), ctx.Conn)
+}
+
+func (s *GameServer) handleListRooms(ctx *Context) error {
+	return writeFrame(ctx.Conn, LIST_ROOMS, s.listRooms())
+}
+
+func (s *GameServer) handleJoinRoom(ctx *Context) error {
+	return s.joinRoomRequest(ctx.Message.(*proto.Room), ctx.Conn)
+}
+
+func (s *GameServer) handleLeaveRoom(ctx *Context) error {
+	return s.joinRoom(s.defaultRoom, ctx.Conn)
+}
@@ -0,0 +1,134 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// historySample is a player's hitbox as it was at the end of a tick.
+type historySample struct {
+	at    time.Time
+	pos   vec3
+	state proto.PLAYER_STATE
+}
+
+// positionHistory is a ring buffer of a player's recent hitboxes, used to rewind them for lag compensation.
+type positionHistory struct {
+	samples []historySample
+	next    int
+	count   int
+}
+
+func newPositionHistory(size int) *positionHistory {
+	if size < 2 {
+		size = 2
+	}
+	return &positionHistory{samples: make([]historySample, size)}
+}
+
+func (h *positionHistory) push(sample historySample) {
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	if h.count < len(h.samples) {
+		h.count++
+	}
+}
+
+func (h *positionHistory) reset() {
+	h.next = 0
+	h.count = 0
+}
+
+// get returns the i-th oldest sample.
+func (h *positionHistory) get(i int) historySample {
+	start := (h.next - h.count + len(h.samples)) % len(h.samples)
+	return h.samples[(start+i)%len(h.samples)]
+}
+
+// at returns the hitbox at time t, interpolating between the two samples around it. Times before the
+// oldest sample return the oldest one and times after the newest return false, meaning the current
+// position should be used.
+func (h *positionHistory) at(t time.Time) (historySample, bool) {
+	if h.count == 0 || !t.Before(h.get(h.count-1).at) {
+		return historySample{}, false
+	}
+
+	oldest := h.get(0)
+	if !t.After(oldest.at) {
+		return oldest, true
+	}
+
+	for i := h.count - 1; i > 0; i-- {
+		before := h.get(i - 1)
+		if t.Before(before.at) {
+			continue
+		}
+		after := h.get(i)
+		f := float32(t.Sub(before.at)) / float32(after.at.Sub(before.at))
+		return historySample{
+			at:    t,
+			pos:   before.pos.add(after.pos.sub(before.pos).scale(f)),
+			state: before.state,
+		}, true
+	}
+	return oldest, true
+}
+
+// historySize returns how many tick samples are needed to cover the maximum rewind window.
+func (r *Room) historySize() int {
+	tick := time.Second / time.Duration(r.config.TickRate)
+	return int(r.config.MaxRewind/tick) + 2
+}
+
+// recordHistory stores every player's current hitbox. Callers must hold r.mu.
+func (r *Room) recordHistory(now time.Time) {
+	r.players.Range(func(key, value interface{}) bool {
+		player := value.(*proto.Player)
+		if len(player.GetPos()) == 0 {
+			return true
+		}
+
+		id := key.(uint32)
+		h, ok := r.history[id]
+		if !ok {
+			h = newPositionHistory(r.historySize())
+			r.history[id] = h
+		}
+		h.push(historySample{at: now, pos: positionVec(player.GetPos()[0]), state: player.GetPlayerState()})
+		return true
+	})
+}
+
+// resetHistory forgets a player's past positions, e.g. after they were teleported. Callers must hold r.mu.
+func (r *Room) resetHistory(id uint32) {
+	if h, ok := r.history[id]; ok {
+		h.reset()
+	}
+}
+
+// viewTime estimates the time at which the world the shooter was looking at was current, clamped to
+// the configured maximum rewind window.
+func (r *Room) viewTime(shooterID uint32, now time.Time) time.Time {
+	rewind := r.config.InterpolationDelay
+	if value, ok := r.conns.Load(shooterID); ok {
+		if sess := sessionOf(value.(*websocket.Conn)); sess != nil {
+			rewind += sess.rtt() / 2
+		}
+	}
+	if rewind > r.config.MaxRewind {
+		rewind = r.config.MaxRewind
+	}
+	return now.Add(-rewind)
+}
+
+// rewoundCapsule returns the player's hitbox as the shooter saw it at time t. Callers must hold r.mu.
+func (r *Room) rewoundCapsule(player *proto.Player, t time.Time) (vec3, vec3, float32) {
+	if h, ok := r.history[player.GetId()]; ok {
+		if sample, ok := h.at(t); ok {
+			return capsule(sample.pos, sample.state)
+		}
+	}
+	return playerCapsule(player)
+}
@@ -0,0 +1,108 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"testing"
+	"time"
+)
+
+func TestLagCompensation(t *testing.T) {
+	// 10 ticks a second keep historySize at 4 samples, 100ms apart.
+	s := New(Config{TickRate: 10, MaxRewind: 200 * time.Millisecond, InterpolationDelay: 50 * time.Millisecond})
+	room := s.defaultRoom
+	c, _, casterID := registerPlayer(t, s, "Caster")
+	_, _, targetID := registerPlayer(t, s, "Target")
+	value, _ := room.players.Load(targetID)
+	target := value.(*proto.Player)
+	target.ProtectedTick = nil
+	health := target.GetHealth()
+	y := target.GetPos()[0].GetY()
+
+	moveTarget := func(x float32) {
+		target.Pos = []*proto.Player_Position{vec3{x, y, 0}.position()}
+	}
+	// The target ran along x, sampled at the end of every tick: x 0 300ms ago, 10, 20 and 30 now.
+	now := time.Now()
+	room.mu.Lock()
+	for i := 0; i < 4; i++ {
+		moveTarget(float32(i * 10))
+		room.recordHistory(now.Add(time.Duration(i-3) * 100 * time.Millisecond))
+	}
+	// The target moved on since the last tick.
+	moveTarget(40)
+	room.mu.Unlock()
+
+	// A caster with a round trip of 100ms saw the world 50ms plus the 50ms interpolation delay ago.
+	sessionOf(c).recordRTT(100 * time.Millisecond)
+	if got, want := room.viewTime(casterID, now), now.Add(-100*time.Millisecond); !got.Equal(want) {
+		t.Fatalf("view time is %v before now, want 100ms", now.Sub(got))
+	}
+
+	p := &projectile{casterID: casterID, radius: 0.1}
+	tests := []struct {
+		name     string
+		viewTime time.Time
+		x        float32
+		hit      bool
+	}{
+		{"at a sample", now.Add(-100 * time.Millisecond), 20, true},
+		{"not where the target is now", now.Add(-100 * time.Millisecond), 40, false},
+		{"between samples", now.Add(-150 * time.Millisecond), 15, true},
+		{"between samples, at the later one", now.Add(-150 * time.Millisecond), 20, false},
+		{"before the oldest sample", now.Add(-time.Second), 0, true},
+		{"after the newest sample", now, 40, true},
+		{"after the newest sample, at it", now, 30, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room.mu.Lock()
+			hit := room.projectileHit(p, vec3{tt.x, y, -1}, vec3{tt.x, y, 1}, tt.viewTime)
+			room.mu.Unlock()
+			if (hit != nil) != tt.hit {
+				t.Fatalf("projectile at x %v hit %v, want a hit: %v", tt.x, hit, tt.hit)
+			}
+		})
+	}
+
+	// A slow caster is not rewound further than MaxRewind, so the target was at x 10.
+	for range 10 {
+		sessionOf(c).recordRTT(2 * time.Second)
+	}
+	viewTime := room.viewTime(casterID, now)
+	if !viewTime.Equal(now.Add(-200 * time.Millisecond)) {
+		t.Fatalf("view time is %v before now, want MaxRewind", now.Sub(viewTime))
+	}
+
+	// Damage is dealt by the tick loop against the caster's view time, not the target's current position.
+	room.mu.Lock()
+	missed, hit := room.ids.Allocate(), room.ids.Allocate()
+	room.projectiles[missed] = &projectile{
+		id:       missed,
+		casterID: casterID,
+		position: vec3{0, y, -1},
+		velocity: vec3{0, 0, 20},
+		radius:   0.1,
+		damage:   10,
+		expires:  now.Add(time.Second),
+	}
+	room.projectiles[hit] = &projectile{
+		id:       hit,
+		casterID: casterID,
+		position: vec3{10, y, -1},
+		velocity: vec3{0, 0, 20},
+		radius:   0.1,
+		damage:   10,
+		expires:  now.Add(time.Second),
+	}
+	room.mu.Unlock()
+	room.stepProjectiles(100*time.Millisecond, now)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	if _, ok := room.projectiles[missed]; !ok {
+		t.Fatal("projectile passing where the target was longer than MaxRewind ago hit")
+	}
+	if _, ok := room.projectiles[hit]; ok || target.GetHealth() != health-10 {
+		t.Fatalf("projectile passing where the caster saw the target missed, target health %v", target.GetHealth())
+	}
+}
@@ -0,0 +1,97 @@
+package gameserver
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// IDAllocator hands out entity IDs: players, projectiles and anything else the server sends to clients
+// by ID. IDs are random, never zero, since clients and broadcasts use zero for "none", and unique among
+// the IDs that have not been released. Released IDs are held back for a while so that late frames
+// carrying them cannot reach the entity that would otherwise get the ID next.
+type IDAllocator struct {
+	mu         sync.Mutex
+	reuseDelay time.Duration
+	live       map[uint32]struct{}
+	// released holds the quarantined IDs in the order they were released, with the time each one
+	// becomes available again in quarantined.
+	released    []uint32
+	quarantined map[uint32]time.Time
+}
+
+// NewIDAllocator returns an allocator that does not hand out released IDs again for reuseDelay.
+func NewIDAllocator(reuseDelay time.Duration) *IDAllocator {
+	return &IDAllocator{
+		reuseDelay:  reuseDelay,
+		live:        make(map[uint32]struct{}),
+		quarantined: make(map[uint32]time.Time),
+	}
+}
+
+// Allocate returns an ID that is neither zero, nor live, nor released within the reuse delay.
+func (a *IDAllocator) Allocate() uint32 {
+	return a.allocate(time.Now())
+}
+
+func (a *IDAllocator) allocate(now time.Time) uint32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.expire(now)
+	for {
+		id := rand.Uint32()
+		if id == 0 {
+			continue
+		}
+		if _, ok := a.live[id]; ok {
+			continue
+		}
+		if _, ok := a.quarantined[id]; ok {
+			continue
+		}
+		a.live[id] = struct{}{}
+		return id
+	}
+}
+
+// Release returns an ID to the allocator once its entity is gone. Releasing an ID that is not live
+// does nothing.
+func (a *IDAllocator) Release(id uint32) {
+	a.release(id, time.Now())
+}
+
+func (a *IDAllocator) release(id uint32, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.live[id]; !ok {
+		return
+	}
+	delete(a.live, id)
+	if a.reuseDelay > 0 {
+		a.released = append(a.released, id)
+		a.quarantined[id] = now.Add(a.reuseDelay)
+	}
+	a.expire(now)
+}
+
+// Live returns the number of allocated IDs that have not been released.
+func (a *IDAllocator) Live() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.live)
+}
+
+// expire ends the quarantine of the IDs whose reuse delay has passed. Callers must hold a.mu.
+func (a *IDAllocator) expire(now time.Time) {
+	n := 0
+	for _, id := range a.released {
+		if now.Before(a.quarantined[id]) {
+			break
+		}
+		delete(a.quarantined, id)
+		n++
+	}
+	a.released = a.released[n:]
+}
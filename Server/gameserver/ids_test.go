@@ -0,0 +1,65 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIDAllocator(t *testing.T) {
+	a := NewIDAllocator(time.Second)
+	now := time.Now()
+
+	ids := make(map[uint32]bool)
+	for i := 0; i < 10000; i++ {
+		id := a.allocate(now)
+		if id == 0 {
+			t.Fatal("allocated ID 0")
+		}
+		if ids[id] {
+			t.Fatalf("ID %d allocated twice", id)
+		}
+		ids[id] = true
+	}
+
+	for id := range ids {
+		a.release(id, now)
+	}
+	if live := a.Live(); live != 0 {
+		t.Fatalf("%d IDs live after releasing all of them", live)
+	}
+	for i := 0; i < 10000; i++ {
+		if id := a.allocate(now.Add(time.Second / 2)); ids[id] {
+			t.Fatalf("ID %d reused within the reuse delay", id)
+		}
+	}
+
+	a.allocate(now.Add(time.Second))
+	if len(a.quarantined) != 0 || len(a.released) != 0 {
+		t.Fatalf("%d IDs still quarantined after the reuse delay", len(a.quarantined))
+	}
+}
+
+func TestIDReuseDelay(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+		want  time.Duration
+	}{
+		{"default", 0, DefaultConfig().IDReuseDelay},
+		{"configured", time.Minute, time.Minute},
+		{"disabled", -1, -1},
+	}
+	for _, test := range tests {
+		s := New(Config{IDReuseDelay: test.delay})
+		if s.config.IDReuseDelay != test.want {
+			t.Errorf("%s: reuse delay is %v, want %v", test.name, s.config.IDReuseDelay, test.want)
+		}
+	}
+
+	a := New(Config{IDReuseDelay: -1}).IDs()
+	id := a.Allocate()
+	a.Release(id)
+	if len(a.quarantined) != 0 || len(a.released) != 0 {
+		t.Fatalf("ID %d was held back with the reuse delay turned off", id)
+	}
+}
@@ -0,0 +1,52 @@
+package gameserver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// ping queues a WebSocket ping carrying the send time for every connection in the room, registered or
+// not. Pings go through the outbox like any other frame, so they are never written alongside another.
+func (r *Room) ping(now time.Time) {
+	payload := binary.BigEndian.AppendUint64(nil, uint64(now.UnixNano()))
+	r.sessions.Range(func(key, _ interface{}) bool {
+		err := key.(*session).out.push(outboundFrame{data: payload, ping: true}, now)
+		if err != nil {
+			fmt.Println("Failed to send ping to client:", err)
+		}
+		return true
+	})
+}
+
+// onPong measures the round trip time of a ping sent by Room.ping. Any pong counts as traffic for the
+// idle timeout.
+func (s *GameServer) onPong(c *websocket.Conn, appData string) {
+	if sess := sessionOf(c); sess != nil {
+		sess.received(time.Now())
+	}
+	if len(appData) != 8 {
+		return
+	}
+	sent := time.Unix(0, int64(binary.BigEndian.Uint64([]byte(appData))))
+	rtt := time.Since(sent)
+	if rtt < 0 || rtt > time.Minute {
+		return
+	}
+	if sess := sessionOf(c); sess != nil {
+		sess.recordRTT(rtt)
+	}
+}
+
+// Latency returns the smoothed round trip time and jitter of the player's connection. ok is false if
+// the player has no connection in the room.
+func (r *Room) Latency(playerID uint32) (rtt, jitter time.Duration, ok bool) {
+	value, ok := r.conns.Load(playerID)
+	if !ok {
+		return 0, 0, false
+	}
+	rtt, jitter = sessionOf(value.(*websocket.Conn)).latency()
+	return rtt, jitter, true
+}
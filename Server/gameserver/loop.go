@@ -0,0 +1,101 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Tick returns the number of simulation steps the room has run.
+func (r *Room) Tick() uint64 {
+	return r.tick.Load()
+}
+
+func (r *Room) stamp() *uint64 {
+	return proto2.Uint64(r.tick.Load())
+}
+
+// run is the room's game loop. The simulation advances in fixed steps of 1/TickRate seconds measured
+// against the wall clock, so a late wake-up runs the overdue steps back to back, up to MaxCatchUpTicks.
+// Snapshots are sent independently at SendRate.
+func (r *Room) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(time.Second / time.Duration(r.config.TickRate))
+	defer ticker.Stop()
+	pingTicker := time.NewTicker(r.config.PingInterval)
+	defer pingTicker.Stop()
+
+	r.loop(ticker.C, pingTicker.C, time.Now())
+}
+
+// loop runs the game loop on the times received from ticks and pings until the room is stopped. The
+// first simulation step ends one step after start.
+func (r *Room) loop(ticks, pings <-chan time.Time, start time.Time) {
+	dt := time.Second / time.Duration(r.config.TickRate)
+	sendInterval := time.Second / time.Duration(r.config.SendRate)
+
+	nextTick := start.Add(dt)
+	nextSend := nextTick
+
+	for {
+		select {
+		case now := <-pings:
+			r.ping(now)
+			r.checkTimeouts(now)
+		case now := <-ticks:
+			steps := 0
+			for !now.Before(nextTick) && steps < r.config.MaxCatchUpTicks {
+				r.simulate(dt, nextTick)
+				nextTick = nextTick.Add(dt)
+				steps++
+			}
+			if !now.Before(nextTick) {
+				skipped := now.Sub(nextTick)/dt + 1
+				fmt.Printf("Room %d is running behind, skipping %d ticks\n", r.id, skipped)
+				nextTick = nextTick.Add(skipped * dt)
+			}
+
+			if !now.Before(nextSend) {
+				r.sendSnapshots()
+				nextSend = nextSend.Add(sendInterval)
+				if !now.Before(nextSend) {
+					nextSend = now.Add(sendInterval)
+				}
+			}
+		case <-r.stop:
+			r.clearProjectiles()
+			return
+		}
+	}
+}
+
+// simulate runs a single fixed simulation step for the tick ending at now.
+func (r *Room) simulate(dt time.Duration, now time.Time) {
+	r.tick.Add(1)
+	r.mu.Lock()
+	r.stepMovement(dt)
+	events := r.stepCasts(dt)
+	events = append(events, r.stepRespawns()...)
+	events = append(events, r.stepMatch()...)
+	r.mu.Unlock()
+	for _, event := range events {
+		if event.message != nil {
+			r.BroadcastMessage(event.messageType, event.message)
+		}
+	}
+	r.stepProjectiles(dt, now)
+	r.mu.Lock()
+	r.recordHistory(now)
+	r.mu.Unlock()
+}
+
+// marshalPlayer marshals a single player stamped with the current tick. The stored player is left
+// without a tick so snapshots only carry the one on Players. Callers must hold r.mu.
+func (r *Room) marshalPlayer(p *proto.Player) ([]byte, error) {
+	p.Tick = r.stamp()
+	defer func() { p.Tick = nil }()
+	return proto2.Marshal(p)
+}
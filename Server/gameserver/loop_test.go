@@ -0,0 +1,60 @@
+package gameserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoop(t *testing.T) {
+	if s := New(Config{TickRate: 10, SendRate: 20}); s.config.SendRate != 10 {
+		t.Fatalf("SendRate is %d, want it lowered to the TickRate of 10", s.config.SendRate)
+	}
+
+	s := New(Config{TickRate: 10, SendRate: 5, MaxCatchUpTicks: 3})
+	room := s.defaultRoom
+	c, underlying, _ := registerPlayer(t, s, "Looper")
+	waitSent(t, c)
+	seen := len(underlying.messages(t))
+
+	ticks := make(chan time.Time)
+	done := make(chan struct{})
+	start := time.Now()
+	go func() {
+		room.loop(ticks, nil, start)
+		close(done)
+	}()
+
+	tests := []struct {
+		name string
+		at   time.Duration
+		// tick is the room's tick after the wake-up, and snapshots how many have been sent by then.
+		tick      uint64
+		snapshots int
+	}{
+		{"on time", 100 * time.Millisecond, 1, 1},
+		{"early", 150 * time.Millisecond, 1, 1},
+		{"late", 450 * time.Millisecond, 4, 2},
+		{"later than MaxCatchUpTicks", time.Second, 7, 3},
+		{"after skipping ticks", 1100 * time.Millisecond, 8, 3},
+		{"next send", 1200 * time.Millisecond, 9, 4},
+	}
+	for _, tt := range tests {
+		ticks <- start.Add(tt.at)
+		// The loop has handled a wake-up once it receives the next one, so a second send waits for it.
+		ticks <- start.Add(tt.at - time.Hour)
+		waitSent(t, c)
+		snapshots := 0
+		for _, message := range underlying.messages(t)[seen:] {
+			if message[0] == UPDATE_LOCATION {
+				snapshots++
+			}
+		}
+		if room.Tick() != tt.tick || snapshots != tt.snapshots {
+			t.Fatalf("%s: tick %d after %d snapshots, want tick %d after %d", tt.name, room.Tick(), snapshots, tt.tick,
+				tt.snapshots)
+		}
+	}
+
+	room.shutdown()
+	<-done
+}
@@ -0,0 +1,201 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// match is the lifecycle of the match played in a room: waiting for players, warmup, live and post-game.
+type match struct {
+	phase proto.MatchPhase
+	// ends is the tick the phase ends at, 0 while waiting for players.
+	ends uint64
+	// announced is the seconds_left of the last MATCH_STATE broadcast, for the countdown.
+	announced uint32
+}
+
+// stepMatch moves the room's match to its next phase once the current one is over. A warmup starts when
+// Config.MinPlayers connected players are in the room, not counting those parked for a resume. The match
+// goes live after Config.WarmupTime, and ends when a player, or a team in team game modes, reaches
+// Config.ScoreLimit, after Config.TimeLimit, or when too few players are left. Its results are shown for
+// Config.PostGameTime, then scores, health and positions are reset for the next round. Warmup and
+// post-game count down with a MATCH_STATE every second. It returns the events to broadcast. Callers must
+// hold r.mu.
+func (r *Room) stepMatch() []roomEvent {
+	events := r.advanceMatch()
+	for _, event := range events {
+		if event.messageType == MATCH_STATE {
+			r.match.announced = r.secondsLeft()
+		}
+	}
+	return events
+}
+
+// advanceMatch runs a step of the match for stepMatch. Callers must hold r.mu.
+func (r *Room) advanceMatch() []roomEvent {
+	tick := r.Tick()
+	enough := r.connectedCount() >= r.config.MinPlayers
+
+	switch r.match.phase {
+	case proto.MatchPhase_WAITING_FOR_PLAYERS:
+		if enough {
+			return r.setPhase(proto.MatchPhase_WARMUP, r.config.WarmupTime)
+		}
+		return nil
+	case proto.MatchPhase_WARMUP:
+		switch {
+		case !enough:
+			return r.setPhase(proto.MatchPhase_WAITING_FOR_PLAYERS, 0)
+		case tick >= r.match.ends:
+			// Kills scored during the warmup do not count.
+			events := r.resetRound()
+			return append(events, r.setPhase(proto.MatchPhase_LIVE, r.config.TimeLimit)...)
+		}
+	case proto.MatchPhase_LIVE:
+		switch {
+		case r.topScore() >= uint32(r.config.ScoreLimit):
+			return r.endMatch(proto.MatchEndReason_SCORE_LIMIT)
+		case tick >= r.match.ends:
+			return r.endMatch(proto.MatchEndReason_TIME_LIMIT)
+		case !enough:
+			return r.endMatch(proto.MatchEndReason_NOT_ENOUGH_PLAYERS)
+		}
+		return nil
+	case proto.MatchPhase_POST_GAME:
+		if tick >= r.match.ends {
+			events := r.resetRound()
+			if enough {
+				return append(events, r.setPhase(proto.MatchPhase_WARMUP, r.config.WarmupTime)...)
+			}
+			return append(events, r.setPhase(proto.MatchPhase_WAITING_FOR_PLAYERS, 0)...)
+		}
+	}
+
+	if r.secondsLeft() != r.match.announced {
+		return []roomEvent{{MATCH_STATE, r.marshalMatchState()}}
+	}
+	return nil
+}
+
+// setPhase starts a phase of the match lasting d, or until something happens if d is 0, and returns its
+// MATCH_STATE event. Callers must hold r.mu.
+func (r *Room) setPhase(phase proto.MatchPhase, d time.Duration) []roomEvent {
+	r.match.phase = phase
+	r.match.ends = 0
+	if d > 0 {
+		r.match.ends = r.Tick() + r.ticks(d)
+	}
+	fmt.Printf("Room %d: match is %v\n", r.id, phase)
+	return []roomEvent{{MATCH_STATE, r.marshalMatchState()}}
+}
+
+// endMatch ends the live match, returning the MATCH_RESULTS and post-game MATCH_STATE events. Callers
+// must hold r.mu.
+func (r *Room) endMatch(reason proto.MatchEndReason) []roomEvent {
+	results := &proto.MatchResults{Reason: reason.Enum(), Tick: r.stamp()}
+	r.scoreboard.Range(func(_, value interface{}) bool {
+		results.Score = append(results.Score, value.(*proto.Score))
+		return true
+	})
+	sort.Slice(results.Score, func(i, j int) bool {
+		a, b := results.Score[i], results.Score[j]
+		if a.GetScore() != b.GetScore() {
+			return a.GetScore() > b.GetScore()
+		}
+		return a.GetId() < b.GetId()
+	})
+	results.Team = r.teamScores()
+	if teams := results.Team; len(teams) > 0 {
+		if len(teams) == 1 || teams[0].GetScore() > teams[1].GetScore() {
+			results.WinningTeam = proto2.Uint32(teams[0].GetTeam())
+		}
+		fmt.Printf("Room %d: match ended by %v, winning team %d\n", r.id, reason, results.GetWinningTeam())
+	} else {
+		if n := len(results.Score); n == 1 || (n > 1 && results.Score[0].GetScore() > results.Score[1].GetScore()) {
+			results.WinnerId = proto2.Uint32(results.Score[0].GetId())
+		}
+		fmt.Printf("Room %d: match ended by %v, winner %d\n", r.id, reason, results.GetWinnerId())
+	}
+
+	var events []roomEvent
+	byteSlice, protoErr := proto2.Marshal(results)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling MatchResults: %v\n", protoErr)
+	} else {
+		events = append(events, roomEvent{MATCH_RESULTS, byteSlice})
+	}
+	return append(events, r.setPhase(proto.MatchPhase_POST_GAME, r.config.PostGameTime)...)
+}
+
+// resetRound sets every score, of players and teams, to 0 and spawns every player again, returning the
+// RESPAWN_PLAYER and scoreboard events. Callers must hold r.mu.
+func (r *Room) resetRound() []roomEvent {
+	var events []roomEvent
+	r.scoreboard.Range(func(_, value interface{}) bool {
+		value.(*proto.Score).Score = proto2.Uint32(0)
+		return true
+	})
+	clear(r.teamScore)
+	r.players.Range(func(_, value interface{}) bool {
+		if respawned := r.RespawnPlayer(value.(*proto.Player)); respawned != nil {
+			events = append(events, roomEvent{RESPAWN_PLAYER, respawned})
+		}
+		return true
+	})
+	return append(events, roomEvent{REQUEST_SCOREBOARD, r.marshalScoreboard()})
+}
+
+// topScore returns the highest score in the room, that of a team in team game modes. Callers must hold
+// r.mu.
+func (r *Room) topScore() uint32 {
+	if teams := r.teamScores(); len(teams) > 0 {
+		return teams[0].GetScore()
+	}
+	top := uint32(0)
+	r.scoreboard.Range(func(_, value interface{}) bool {
+		top = max(top, value.(*proto.Score).GetScore())
+		return true
+	})
+	return top
+}
+
+// secondsLeft returns the whole seconds left in the current phase, rounded up, or 0 if it has no end.
+// Callers must hold r.mu.
+func (r *Room) secondsLeft() uint32 {
+	tick := r.Tick()
+	if r.match.ends <= tick {
+		return 0
+	}
+	return uint32(math.Ceil(float64(r.match.ends-tick) / float64(r.config.TickRate)))
+}
+
+// MatchState marshals the MatchState sent to players joining the room.
+func (r *Room) MatchState() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.marshalMatchState()
+}
+
+// marshalMatchState marshals the current MatchState. Callers must hold r.mu.
+func (r *Room) marshalMatchState() []byte {
+	state := &proto.MatchState{
+		Phase:      r.match.phase.Enum(),
+		ScoreLimit: proto2.Uint32(uint32(r.config.ScoreLimit)),
+		Tick:       r.stamp(),
+	}
+	if r.match.ends != 0 {
+		state.EndsTick = proto2.Uint64(r.match.ends)
+		state.SecondsLeft = proto2.Uint32(r.secondsLeft())
+	}
+	byteSlice, protoErr := proto2.Marshal(state)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling MatchState: %v\n", protoErr)
+		return nil
+	}
+	return byteSlice
+}
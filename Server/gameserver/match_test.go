@@ -0,0 +1,129 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"testing"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestMatch(t *testing.T) {
+	s := New(Config{TickRate: 10, MinPlayers: 2, WarmupTime: time.Second, ScoreLimit: 2, TimeLimit: time.Minute,
+		PostGameTime: time.Second})
+	room := s.defaultRoom
+	register := func(name string) uint32 {
+		_, _, playerID := registerPlayer(t, s, name)
+		return playerID
+	}
+	now := time.Now()
+	step := func(ticks int) {
+		for i := 0; i < ticks; i++ {
+			now = now.Add(100 * time.Millisecond)
+			room.simulate(100*time.Millisecond, now)
+		}
+	}
+	expectPhase := func(phase proto.MatchPhase) {
+		t.Helper()
+		if room.match.phase != phase {
+			t.Fatalf("match is %v, want %v", room.match.phase, phase)
+		}
+	}
+	kill := func(id uint32) {
+		value, _ := room.scoreboard.Load(id)
+		score := value.(*proto.Score)
+		score.Score = proto2.Uint32(score.GetScore() + 1)
+	}
+
+	alice := register("Alice")
+	step(1)
+	expectPhase(proto.MatchPhase_WAITING_FOR_PLAYERS)
+	bob := register("Bob")
+	step(1)
+	expectPhase(proto.MatchPhase_WARMUP)
+
+	kill(alice)
+	step(10)
+	expectPhase(proto.MatchPhase_LIVE)
+	if room.topScore() != 0 {
+		t.Fatal("the warmup's kills were kept when the match went live")
+	}
+
+	kill(bob)
+	kill(bob)
+	room.mu.Lock()
+	events := room.stepMatch()
+	room.mu.Unlock()
+	expectPhase(proto.MatchPhase_POST_GAME)
+	if len(events) != 2 || events[0].messageType != MATCH_RESULTS {
+		t.Fatalf("reaching the score limit returned %d events, want MATCH_RESULTS and MATCH_STATE", len(events))
+	}
+	results := &proto.MatchResults{}
+	if err := proto2.Unmarshal(events[0].message, results); err != nil {
+		t.Fatal(err)
+	}
+	if results.GetReason() != proto.MatchEndReason_SCORE_LIMIT || results.GetWinnerId() != bob ||
+		len(results.GetScore()) != 2 || results.GetScore()[0].GetId() != bob {
+		t.Fatalf("match ended with %v", results)
+	}
+
+	step(10)
+	expectPhase(proto.MatchPhase_WARMUP)
+	if room.topScore() != 0 {
+		t.Fatal("scores were not reset for the next round")
+	}
+	room.removePlayer(alice)
+	step(1)
+	expectPhase(proto.MatchPhase_WAITING_FOR_PLAYERS)
+}
+
+// TestMatchCountdown checks that sending MATCH_STATE to a joining player does not swallow the countdown.
+func TestMatchCountdown(t *testing.T) {
+	s := New(Config{TickRate: 10, MinPlayers: 1, WarmupTime: 3 * time.Second})
+	room := s.defaultRoom
+	registerPlayer(t, s, "Alice")
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		now = now.Add(100 * time.Millisecond)
+		room.simulate(100*time.Millisecond, now)
+	}
+	if room.match.phase != proto.MatchPhase_WARMUP || room.secondsLeft() != 3 {
+		t.Fatalf("match is %v with %d seconds left, want a warmup with 3", room.match.phase, room.secondsLeft())
+	}
+
+	// A player joins on the tick the countdown reaches 2 seconds, before the match steps.
+	room.tick.Add(1)
+	room.MatchState()
+	room.mu.Lock()
+	events := room.stepMatch()
+	room.mu.Unlock()
+	state := &proto.MatchState{}
+	if len(events) != 1 || events[0].messageType != MATCH_STATE || proto2.Unmarshal(events[0].message, state) != nil ||
+		state.GetSecondsLeft() != 2 {
+		t.Fatalf("countdown step returned %d events, want a MATCH_STATE with 2 seconds left", len(events))
+	}
+}
+
+// TestMatchParkedPlayers checks that players waiting to resume do not count toward MinPlayers.
+func TestMatchParkedPlayers(t *testing.T) {
+	s := New(Config{TickRate: 10, MinPlayers: 2, WarmupTime: time.Second})
+	room := s.defaultRoom
+	registerPlayer(t, s, "Alice")
+	c, _, _ := registerPlayer(t, s, "Bob")
+	step := func() {
+		room.mu.Lock()
+		room.stepMatch()
+		room.mu.Unlock()
+	}
+
+	step()
+	if room.match.phase != proto.MatchPhase_WARMUP {
+		t.Fatalf("match is %v with two players, want WARMUP", room.match.phase)
+	}
+	s.OnClose(c, nil)
+	step()
+	if room.PlayerCount() != 2 || room.match.phase != proto.MatchPhase_WAITING_FOR_PLAYERS {
+		t.Fatalf("match is %v with %d players, one of them parked, want WAITING_FOR_PLAYERS",
+			room.match.phase, room.PlayerCount())
+	}
+}
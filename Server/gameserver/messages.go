@@ -0,0 +1,124 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/lesismal/nbio"
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// Message types, sent as the leading byte of every protocol version 1 frame and in Envelope.type. They
+// are defined by MessageType in envelope.proto.
+const (
+	REQUEST_PLAYERS     = byte(proto.MessageType_REQUEST_PLAYERS)
+	REGISTER            = byte(proto.MessageType_REGISTER)
+	UPDATE_LOCATION     = byte(proto.MessageType_UPDATE_LOCATION)
+	POLL_LOCATIONS      = byte(proto.MessageType_POLL_LOCATIONS)
+	DAMAGE_PLAYER       = byte(proto.MessageType_DAMAGE_PLAYER)
+	INIT_CAST           = byte(proto.MessageType_INIT_CAST)
+	RESPAWN_PLAYER      = byte(proto.MessageType_RESPAWN_PLAYER)
+	REQUEST_SCOREBOARD  = byte(proto.MessageType_REQUEST_SCOREBOARD)
+	PLAYER_DISCONNECT   = byte(proto.MessageType_PLAYER_DISCONNECT)
+	CREATE_ROOM         = byte(proto.MessageType_CREATE_ROOM)
+	LIST_ROOMS          = byte(proto.MessageType_LIST_ROOMS)
+	JOIN_ROOM           = byte(proto.MessageType_JOIN_ROOM)
+	LEAVE_ROOM          = byte(proto.MessageType_LEAVE_ROOM)
+	PROJECTILE_SPAWN    = byte(proto.MessageType_PROJECTILE_SPAWN)
+	PROJECTILE_HIT      = byte(proto.MessageType_PROJECTILE_HIT)
+	PROJECTILE_DESPAWN  = byte(proto.MessageType_PROJECTILE_DESPAWN)
+	SNAPSHOT_ACK        = byte(proto.MessageType_SNAPSHOT_ACK)
+	RELEVANCE_ENTER     = byte(proto.MessageType_RELEVANCE_ENTER)
+	RELEVANCE_LEAVE     = byte(proto.MessageType_RELEVANCE_LEAVE)
+	INPUT               = byte(proto.MessageType_INPUT)
+	POSITION_CORRECTION = byte(proto.MessageType_POSITION_CORRECTION)
+	HELLO               = byte(proto.MessageType_HELLO)
+	ERROR               = byte(proto.MessageType_ERROR)
+	RESUME              = byte(proto.MessageType_RESUME)
+	TIMEOUT_WARNING     = byte(proto.MessageType_TIMEOUT_WARNING)
+	SELECT_SPELL        = byte(proto.MessageType_SELECT_SPELL)
+	MATCH_STATE         = byte(proto.MessageType_MATCH_STATE)
+	MATCH_RESULTS       = byte(proto.MessageType_MATCH_RESULTS)
+	SWITCH_TEAM         = byte(proto.MessageType_SWITCH_TEAM)
+)
+
+// OnOpen is called by the upgrader when a new connection is established and places it in the default room.
+func (s *GameServer) OnOpen(c *websocket.Conn) {
+	sess := &session{out: newOutbox(c, s.config), lastReceived: time.Now()}
+	sess.set(0, s.defaultRoom)
+	c.SetSession(sess)
+	if conn, ok := c.Conn.(*nbio.Conn); ok {
+		sess.out.track()
+		s.outboxes.Store(conn, sess.out)
+	}
+	go sess.out.run()
+	fmt.Println("OnOpen:", c.RemoteAddr().String())
+}
+
+// onWritten passes on the bytes the network engine wrote to a connection's socket to its outbox.
+func (s *GameServer) onWritten(c *nbio.Conn, _ []byte, n int) {
+	if out, ok := s.outboxes.Load(c); ok {
+		out.(*outbox).flushed(n)
+	}
+}
+
+// OnClose parks the player owning the connection until it resumes, or if it cannot be resumed removes it
+// from its room, notifies the remaining clients and releases the player's ID.
+func (s *GameServer) OnClose(c *websocket.Conn, err error) {
+	if sess := sessionOf(c); sess != nil {
+		playerID, room, parked := s.detach(c)
+		if playerID != 0 && !parked {
+			room.removePlayer(playerID)
+			s.ids.Release(playerID)
+		}
+		sess.out.close()
+		s.outboxes.Delete(c.Conn)
+		s.removeRoomIfEmpty(room)
+	}
+	fmt.Println("OnClose:", c.RemoteAddr().String(), err)
+}
+
+// OnMessage decodes an inbound frame and dispatches it to the handler registered for its message type.
+// Frames that cannot be handled are answered with an ERROR frame; a panic only fails the frame that
+// caused it.
+func (s *GameServer) OnMessage(c *websocket.Conn, messageType websocket.MessageType, _data []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Recovered from panic handling a frame from %s: %v\n%s", c.RemoteAddr().String(), r, debug.Stack())
+			sendError(c, -1, newError(proto.ErrorCode_INTERNAL_ERROR, "internal error"))
+		}
+	}()
+
+	sessionOf(c).received(time.Now())
+	switch messageType {
+	case websocket.TextMessage:
+		fmt.Println("Received a text message, which is not expected.")
+		sendError(c, -1, newError(proto.ErrorCode_MALFORMED_FRAME, "text frames are not supported"))
+	case websocket.BinaryMessage:
+		msgType, data, err := readFrame(c, _data)
+		if err != nil {
+			fmt.Printf("Error reading frame: %v\n", err)
+			sendError(c, -1, err)
+			return
+		}
+		if msgType != HELLO && !sessionOf(c).handshaken() && s.config.MinProtocolVersion > legacyProtocol {
+			s.reject(c, fmt.Sprintf("send HELLO first, the server requires at least protocol version %d",
+				s.config.MinProtocolVersion))
+			return
+		}
+
+		playerID, room := sessionOf(c).get()
+		s.router.dispatch(&Context{
+			Server:   s,
+			Conn:     c,
+			Type:     msgType,
+			PlayerID: playerID,
+			Room:     room,
+			Data:     data,
+		})
+	default:
+		fmt.Printf("Received unexpected message type: %v\n", messageType)
+	}
+}
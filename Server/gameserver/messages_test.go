@@ -0,0 +1,198 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"encoding/binary"
+	"net"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+var testAddr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000}
+
+// recordingConn is a net.Conn that keeps everything written to it and never has anything to read.
+type recordingConn struct {
+	mu      sync.Mutex
+	written []byte
+	closed  bool
+}
+
+func (c *recordingConn) Read([]byte) (int, error) { return 0, net.ErrClosed }
+func (c *recordingConn) LocalAddr() net.Addr      { return testAddr }
+func (c *recordingConn) RemoteAddr() net.Addr     { return testAddr }
+
+func (c *recordingConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *recordingConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *recordingConn) SetDeadline(time.Time) error      { return nil }
+func (c *recordingConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *recordingConn) SetWriteDeadline(time.Time) error { return nil }
+
+func (c *recordingConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = append(c.written, b...)
+	return len(b), nil
+}
+
+// messages splits the recorded bytes into the payloads of the unmasked WebSocket frames the server wrote.
+func (c *recordingConn) messages(t testing.TB) [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var messages [][]byte
+	data := c.written
+	for len(data) > 0 {
+		if len(data) < 2 {
+			t.Fatalf("truncated frame header")
+		}
+		length, header := uint64(data[1]&0x7f), 2
+		switch length {
+		case 126:
+			length, header = uint64(binary.BigEndian.Uint16(data[2:4])), 4
+		case 127:
+			length, header = binary.BigEndian.Uint64(data[2:10]), 10
+		}
+		if uint64(len(data)-header) < length {
+			t.Fatalf("truncated frame payload")
+		}
+		messages = append(messages, data[header:uint64(header)+length])
+		data = data[uint64(header)+length:]
+	}
+	return messages
+}
+
+// openTestConn opens a connection to s that is not backed by a network connection.
+func openTestConn(s *GameServer) (*websocket.Conn, *recordingConn) {
+	underlying := &recordingConn{}
+	c := websocket.NewServerConn(s.upgrader, underlying, "", false, false)
+	s.OnOpen(c)
+	return c, underlying
+}
+
+// waitSent waits until the connection's outbox has written every queued frame.
+func waitSent(t testing.TB, c *websocket.Conn) {
+	deadline := time.Now().Add(time.Second)
+	for !sessionOf(c).out.idle() {
+		if time.Now().After(deadline) {
+			t.Fatalf("outbox did not drain")
+		}
+		runtime.Gosched()
+	}
+}
+
+// errorCode returns the code of an ERROR message in either framing.
+func errorCode(message []byte) (proto.ErrorCode, bool) {
+	payload := []byte(nil)
+	if len(message) > 0 && message[0] == ERROR {
+		payload = message[1:]
+	} else {
+		envelope := proto.Envelope{}
+		if proto2.Unmarshal(message, &envelope) != nil || envelope.GetType() != proto.MessageType_ERROR {
+			return 0, false
+		}
+		payload = envelope.GetPayload()
+	}
+
+	e := proto.Error{}
+	if proto2.Unmarshal(payload, &e) != nil {
+		return 0, false
+	}
+	return e.GetCode(), true
+}
+
+// testPlayer returns the Player the Godot client registers with.
+func testPlayer(name string) *proto.Player {
+	state := proto.PLAYER_STATE_STANDING
+	return &proto.Player{
+		Name:         proto2.String(name),
+		Id:           proto2.Uint32(0),
+		PlayerColor:  proto2.String("#ff0000"),
+		RotationY:    proto2.Float32(0),
+		RotationX:    proto2.Float32(0),
+		Health:       proto2.Float32(100),
+		CurrentSpell: proto2.Uint32(0),
+		Casting:      proto2.Bool(false),
+		PlayerState:  &state,
+	}
+}
+
+// registerPlayer opens a connection to s and registers a player named name on it, returning the
+// connection and the player's ID.
+func registerPlayer(t testing.TB, s *GameServer, name string) (*websocket.Conn, *recordingConn, uint32) {
+	t.Helper()
+	c, underlying := openTestConn(s)
+	s.OnMessage(c, websocket.BinaryMessage, frame(REGISTER, testPlayer(name)))
+	playerID, _ := sessionOf(c).get()
+	if playerID == 0 {
+		t.Fatalf("%s was not registered", name)
+	}
+	return c, underlying, playerID
+}
+
+func frame(messageType byte, message proto2.Message) []byte {
+	byteSlice, err := proto2.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+	return append([]byte{messageType}, byteSlice...)
+}
+
+// FuzzOnMessage sends two arbitrary frames on a fresh connection and fails if handling them panicked.
+func FuzzOnMessage(f *testing.F) {
+	player := testPlayer("Happy Computer")
+	register := frame(REGISTER, player)
+	hello := frame(HELLO, &proto.Hello{ProtocolVersion: proto2.Uint32(ProtocolVersion)})
+	envelope, _ := proto2.Marshal(&proto.Envelope{
+		Type:    proto.MessageType_REGISTER.Enum(),
+		Payload: register[1:],
+	})
+
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{REGISTER}, []byte{UPDATE_LOCATION})
+	f.Add([]byte{REGISTER, 0xff, 0xff}, []byte{INIT_CAST})
+	f.Add(register, []byte{UPDATE_LOCATION})
+	f.Add(register, register)
+	f.Add(register, frame(UPDATE_LOCATION, player))
+	f.Add(register, []byte{INIT_CAST})
+	f.Add(register, frame(INPUT, &proto.Input{Sequence: proto2.Uint32(1), RotationY: proto2.Float32(0), RotationX: proto2.Float32(0)}))
+	f.Add(register, frame(JOIN_ROOM, &proto.Room{Id: proto2.Uint32(1234)}))
+	f.Add(register, []byte{LEAVE_ROOM})
+	f.Add(frame(CREATE_ROOM, &proto.Room{Name: proto2.String("Fuzz")}), register)
+	f.Add([]byte{SNAPSHOT_ACK}, []byte{REQUEST_PLAYERS})
+	f.Add([]byte{255, 1, 2, 3}, []byte{REQUEST_SCOREBOARD})
+	f.Add(hello, envelope)
+	f.Add(hello, []byte{REGISTER})
+	f.Add([]byte{HELLO}, hello)
+	f.Add(frame(RESUME, &proto.Resume{Token: proto2.String("0123456789abcdef0123456789abcdef")}), register)
+
+	s := New(Config{})
+	f.Fuzz(func(t *testing.T, first, second []byte) {
+		c, underlying := openTestConn(s)
+		s.OnMessage(c, websocket.BinaryMessage, first)
+		s.OnMessage(c, websocket.BinaryMessage, second)
+		waitSent(t, c)
+		s.OnClose(c, nil)
+
+		for _, message := range underlying.messages(t) {
+			if code, ok := errorCode(message); ok && code == proto.ErrorCode_INTERNAL_ERROR {
+				t.Fatalf("handling %v then %v panicked", first, second)
+			}
+		}
+	})
+}
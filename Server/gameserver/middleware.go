@@ -0,0 +1,148 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Recover turns a panic in a handler into an INTERNAL_ERROR, so a bad message only fails itself instead
+// of crashing the server.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					fmt.Printf("Recovered from panic in %s handler: %v\n%s", messageName(ctx.Type), r, debug.Stack())
+					err = newError(proto.ErrorCode_INTERNAL_ERROR, "internal error handling %s", messageName(ctx.Type))
+				}
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// Logger prints every message with its sender and how long the handler took.
+func Logger() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			start := time.Now()
+			err := next(ctx)
+			fmt.Printf("%s from %s (player %d) took %v\n", messageName(ctx.Type), ctx.Conn.RemoteAddr().String(),
+				ctx.PlayerID, time.Since(start))
+			return err
+		}
+	}
+}
+
+// RequireRegistration rejects the given message types from connections that have not registered.
+func RequireRegistration(messageTypes ...byte) Middleware {
+	required := make(map[byte]bool, len(messageTypes))
+	for _, messageType := range messageTypes {
+		required[messageType] = true
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if ctx.PlayerID == 0 && required[ctx.Type] {
+				return ErrNotRegistered
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// tokenBucket allows burst messages at once and refills at rate messages per second.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(rate float64, burst int, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.last.IsZero() {
+		b.tokens = float64(burst)
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * rate
+		if b.tokens > float64(burst) {
+			b.tokens = float64(burst)
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RateLimit drops messages beyond rate per second, with bursts of up to burst messages, per connection.
+func RateLimit(rate float64, burst int) Middleware {
+	// key identifies this limiter's state among the connection's middleware state.
+	key := new(int)
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			bucket := ctx.ConnState(key, func() interface{} { return &tokenBucket{} }).(*tokenBucket)
+			if !bucket.take(rate, burst, time.Now()) {
+				return ErrRateLimited
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// MessageStats are the counters Metrics keeps per message type.
+type MessageStats struct {
+	Count  uint64
+	Errors uint64
+	// Time is the total time spent in the handler.
+	Time time.Duration
+}
+
+// Metrics counts the messages, handler errors and handler time of every message type.
+type Metrics struct {
+	mu    sync.Mutex
+	stats map[byte]*MessageStats
+}
+
+// Middleware returns the middleware that feeds the metrics.
+func (m *Metrics) Middleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			start := time.Now()
+			err := next(ctx)
+			elapsed := time.Since(start)
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if m.stats == nil {
+				m.stats = make(map[byte]*MessageStats)
+			}
+			stats, ok := m.stats[ctx.Type]
+			if !ok {
+				stats = &MessageStats{}
+				m.stats[ctx.Type] = stats
+			}
+			stats.Count++
+			stats.Time += elapsed
+			if err != nil {
+				stats.Errors++
+			}
+			return err
+		}
+	}
+}
+
+// Stats returns a copy of the counters, keyed by message type.
+func (m *Metrics) Stats() map[byte]MessageStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := make(map[byte]MessageStats, len(m.stats))
+	for messageType, s := range m.stats {
+		stats[messageType] = *s
+	}
+	return stats
+}
@@ -0,0 +1,160 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"math"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// floorY is the height of a standing player's center when their feet touch the arena floor.
+const floorY = playerHeight / 2
+
+// input is a single movement command, applied for exactly one simulation step.
+type input struct {
+	sequence uint32
+	moveX    float32
+	moveZ    float32
+	jump     bool
+	crouch   bool
+	rotY     float32
+	rotX     float32
+}
+
+// movement is the server-side movement state of a player that sends inputs. Players with a movement
+// state are moved by the server only; their UPDATE_LOCATION frames are ignored.
+type movement struct {
+	// pending holds the received inputs that have not been applied yet, oldest first.
+	pending   []input
+	last      input
+	velocityY float32
+}
+
+// QueueInput queues an INPUT from the player for the next simulation steps. Inputs that are not newer
+// than the last queued or applied one are dropped, as are the oldest ones once Config.InputBuffer is full.
+// Inputs with NaN or infinite fields are dropped and count as movement violations. It reports whether
+// the player reached Config.MaxMoveViolations and should be kicked.
+func (r *Room) QueueInput(playerID uint32, in *proto.Input) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.players.Load(playerID); !ok {
+		return false
+	}
+	if !finite(in.GetMoveX(), in.GetMoveZ(), in.GetRotationY(), in.GetRotationX()) {
+		return r.recordViolation(playerID)
+	}
+	m, ok := r.movement[playerID]
+	if !ok {
+		m = &movement{}
+		r.movement[playerID] = m
+	}
+
+	newest := m.last.sequence
+	if len(m.pending) > 0 {
+		newest = m.pending[len(m.pending)-1].sequence
+	}
+	if in.GetSequence() <= newest {
+		return false
+	}
+
+	m.pending = append(m.pending, input{
+		sequence: in.GetSequence(),
+		moveX:    in.GetMoveX(),
+		moveZ:    in.GetMoveZ(),
+		jump:     in.GetJump(),
+		crouch:   in.GetCrouch(),
+		rotY:     in.GetRotationY(),
+		rotX:     in.GetRotationX(),
+	})
+	if len(m.pending) > r.config.InputBuffer {
+		m.pending = m.pending[len(m.pending)-r.config.InputBuffer:]
+	}
+	return false
+}
+
+// stepMovement applies the next pending input of every player that sends inputs. Players whose input
+// has not arrived yet stand still, keeping their last look direction and crouch. Dead players do not
+// move. Callers must hold r.mu.
+func (r *Room) stepMovement(dt time.Duration) {
+	for id, m := range r.movement {
+		value, ok := r.players.Load(id)
+		if !ok {
+			continue
+		}
+		player := value.(*proto.Player)
+		if player.GetPlayerState() == proto.PLAYER_STATE_DEAD {
+			// Dead players do not move, and the inputs sent while dead are dropped.
+			if len(m.pending) > 0 {
+				m.last.sequence = m.pending[len(m.pending)-1].sequence
+				m.pending = nil
+			}
+			continue
+		}
+
+		in := input{sequence: m.last.sequence, crouch: m.last.crouch, rotY: m.last.rotY, rotX: m.last.rotX}
+		if len(m.pending) > 0 {
+			in = m.pending[0]
+			m.pending = m.pending[1:]
+		}
+		m.last = in
+		r.move(player, m, in, float32(dt.Seconds()))
+	}
+}
+
+// move advances a player by one step of dt seconds, the way player.gd moves the local player.
+func (r *Room) move(player *proto.Player, m *movement, in input, dt float32) {
+	var pos vec3
+	if len(player.GetPos()) > 0 {
+		pos = positionVec(player.GetPos()[0])
+	}
+
+	onFloor := pos.y <= floorY
+	if !onFloor {
+		m.velocityY -= r.config.Gravity * dt
+	} else if in.jump {
+		m.velocityY = r.config.JumpVelocity
+	}
+
+	// Rotate the input into world space, like transform.basis * Vector3(x, 0, z) in Godot.
+	local := vec3{in.moveX, 0, in.moveZ}
+	if l := local.length(); l > 1 {
+		local = local.scale(1 / l)
+	}
+	sin, cos := math.Sincos(float64(in.rotY))
+	direction := vec3{
+		local.x*float32(cos) + local.z*float32(sin),
+		0,
+		-local.x*float32(sin) + local.z*float32(cos),
+	}
+
+	pos = pos.add(direction.scale(r.config.MoveSpeed * dt))
+	pos.y += m.velocityY * dt
+	if pos.y <= floorY {
+		pos.y = floorY
+		m.velocityY = 0
+	}
+	pos, _ = clampToArena(pos)
+
+	state := proto.PLAYER_STATE_STANDING
+	switch {
+	case pos.y > floorY:
+		state = proto.PLAYER_STATE_JUMPING
+	case in.crouch:
+		state = proto.PLAYER_STATE_CROUCHING
+	}
+
+	player.Pos = []*proto.Player_Position{pos.position()}
+	player.RotationY = proto2.Float32(in.rotY)
+	player.RotationX = proto2.Float32(in.rotX)
+	player.PlayerState = &state
+	player.LastInput = proto2.Uint32(in.sequence)
+}
+
+// resetMovement stops a player's jump or fall, e.g. after they were teleported. Callers must hold r.mu.
+func (r *Room) resetMovement(id uint32) {
+	if m, ok := r.movement[id]; ok {
+		m.velocityY = 0
+	}
+}
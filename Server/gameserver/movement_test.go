@@ -0,0 +1,69 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"math"
+	"testing"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestMovement(t *testing.T) {
+	s := New(Config{TickRate: 10, MaxMoveViolations: 100})
+	room := s.defaultRoom
+	_, _, playerID := registerPlayer(t, s, "Walker")
+	value, _ := room.players.Load(playerID)
+	player := value.(*proto.Player)
+	sequence, violations := uint32(0), 0
+
+	tests := []struct {
+		name              string
+		moveX, moveZ      float32
+		rotationY         float32
+		start, want       vec3
+		violation, queued bool
+	}{
+		{"forward", 0, -1, 0, vec3{0, floorY, 0}, vec3{0, floorY, -0.5}, false, true},
+		{"turned", 0, -1, math.Pi / 2, vec3{0, floorY, 0}, vec3{-0.5, floorY, 0}, false, true},
+		{"diagonal is normalised", 1, 1, 0, vec3{0, floorY, 0}, vec3{0.5 / math.Sqrt2, floorY, 0.5 / math.Sqrt2}, false, true},
+		{"clamped to the arena", 1, 0, 0, vec3{8.8, floorY, 0}, vec3{9, floorY, 0}, false, true},
+		{"NaN move", float32(math.NaN()), 0, 0, vec3{0, floorY, 0}, vec3{0, floorY, 0}, true, false},
+		{"infinite rotation", 1, 0, float32(math.Inf(1)), vec3{0, floorY, 0}, vec3{0, floorY, 0}, true, false},
+	}
+	for _, test := range tests {
+		room.mu.Lock()
+		player.Pos = []*proto.Player_Position{test.start.position()}
+		room.mu.Unlock()
+
+		sequence++
+		room.QueueInput(playerID, &proto.Input{
+			Sequence:  proto2.Uint32(sequence),
+			MoveX:     proto2.Float32(test.moveX),
+			MoveZ:     proto2.Float32(test.moveZ),
+			RotationY: proto2.Float32(test.rotationY),
+			RotationX: proto2.Float32(0),
+		})
+		room.simulate(100*time.Millisecond, time.Now())
+
+		room.mu.Lock()
+		got := positionVec(player.GetPos()[0])
+		applied := player.GetLastInput() == sequence
+		room.mu.Unlock()
+		if got.sub(test.want).length() > 1e-5 {
+			t.Errorf("%s: moved to %v, want %v", test.name, got, test.want)
+		}
+		if applied != test.queued {
+			t.Errorf("%s: input applied %v, want %v", test.name, applied, test.queued)
+		}
+		if !finite(got.x, got.y, got.z, player.GetRotationY()) {
+			t.Errorf("%s: player state is not finite", test.name)
+		}
+		if test.violation {
+			violations++
+		}
+		if got := s.MoveViolations(playerID); got != violations {
+			t.Errorf("%s: %d violations, want %d", test.name, got, violations)
+		}
+	}
+}
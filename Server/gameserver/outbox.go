@@ -0,0 +1,252 @@
+package gameserver
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the Envelope message in envelope.proto.
+const (
+	envelopeTypeField     protowire.Number = 1
+	envelopeSequenceField protowire.Number = 2
+	envelopePayloadField  protowire.Number = 3
+)
+
+// outboundFrame is a frame waiting in an outbox.
+type outboundFrame struct {
+	data []byte
+	// envelope wraps the frame in an Envelope when it is written.
+	envelope bool
+	// closeAfter closes the connection once the frame is written.
+	closeAfter bool
+	// ping sends data as the payload of a WebSocket ping instead of a binary message.
+	ping bool
+	// shared is the pooled frame data belongs to, released once the frame is written or dropped.
+	shared *sharedFrame
+}
+
+func (f outboundFrame) release() {
+	if f.shared != nil {
+		f.shared.release()
+	}
+}
+
+// outbox is the bounded send queue of a connection, drained by its own writer goroutine so that a slow
+// client never holds up the room sending to it. When the queue is full the oldest queued state
+// snapshot is dropped, since the new frame supersedes it, and the client is disconnected if there is
+// none. A client whose queue stays full for longer than Config.SlowClientTimeout is disconnected too.
+//
+// Writes to a connection of the network engine never block: the engine buffers what the client has not
+// read yet. The writer therefore stops draining the queue while Config.SendBufferSize bytes it wrote are
+// still buffered, see flushed, so a slow client backs up into the queue instead of the engine's buffer.
+type outbox struct {
+	conn     *websocket.Conn
+	capacity int
+	timeout  time.Duration
+	// bufferSize is Config.SendBufferSize, and buffered the bytes written to a connection of the network
+	// engine that it has not passed on to the socket yet. tracked is set for those connections.
+	bufferSize int
+	buffered   int
+	tracked    bool
+
+	mu      sync.Mutex
+	frames  []outboundFrame
+	writing bool
+	closed  bool
+	// behindSince is when the queue filled up, zero once it has room again.
+	behindSince time.Time
+	// sequence is the sequence of the last Envelope written.
+	sequence uint32
+	// buffer is reused by the writer to wrap frames in an Envelope.
+	buffer []byte
+
+	wake chan struct{}
+	stop chan struct{}
+}
+
+func newOutbox(c *websocket.Conn, config Config) *outbox {
+	return &outbox{
+		conn:       c,
+		capacity:   config.SendQueueSize,
+		timeout:    config.SlowClientTimeout,
+		bufferSize: config.SendBufferSize,
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+}
+
+// track makes the writer wait for flushed to report its writes reaching the socket.
+func (o *outbox) track() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.tracked = true
+}
+
+// flushed records that the network engine passed n bytes of the connection on to the socket, waking the
+// writer once less than Config.SendBufferSize bytes are left buffered.
+func (o *outbox) flushed(n int) {
+	o.mu.Lock()
+	// The engine also writes frames of its own, such as pongs, that were never counted.
+	o.buffered = max(0, o.buffered-n)
+	o.mu.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// push queues a frame for the writer.
+func (o *outbox) push(frame outboundFrame, now time.Time) error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return net.ErrClosed
+	}
+
+	if len(o.frames) >= o.capacity {
+		if o.behindSince.IsZero() {
+			o.behindSince = now
+		}
+		var reason string
+		switch {
+		case now.Sub(o.behindSince) > o.timeout:
+			reason = fmt.Sprintf("its send queue has been full for %v", o.timeout)
+		case !o.dropSnapshot():
+			// Only snapshots can be left out without the client falling out of step with the server.
+			reason = "its send queue is full without a snapshot to drop"
+		}
+		if reason != "" {
+			o.closed = true
+			o.mu.Unlock()
+			fmt.Printf("Disconnecting %s, %s\n", o.conn.RemoteAddr().String(), reason)
+			o.conn.Close()
+			return net.ErrClosed
+		}
+	}
+
+	o.frames = append(o.frames, frame)
+	o.mu.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// dropSnapshot removes the oldest queued state snapshot, reporting whether there was one. Callers must
+// hold o.mu.
+func (o *outbox) dropSnapshot() bool {
+	for i, frame := range o.frames {
+		if !frame.ping && len(frame.data) > 0 && frame.data[0] == UPDATE_LOCATION {
+			frame.release()
+			o.frames = append(o.frames[:i], o.frames[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// run writes queued frames until the outbox is closed.
+func (o *outbox) run() {
+	for {
+		select {
+		case <-o.wake:
+		case <-o.stop:
+			return
+		}
+
+		for {
+			o.mu.Lock()
+			if len(o.frames) == 0 || o.closed {
+				o.writing = false
+				o.mu.Unlock()
+				break
+			}
+			if o.tracked && o.buffered >= o.bufferSize {
+				// The frames stay queued, where snapshots can be dropped, until flushed wakes the writer.
+				o.writing = false
+				o.mu.Unlock()
+				break
+			}
+			frame := o.frames[0]
+			o.frames[0] = outboundFrame{}
+			o.frames = o.frames[1:]
+			if len(o.frames) < o.capacity {
+				o.behindSince = time.Time{}
+			}
+			o.writing = true
+			o.mu.Unlock()
+
+			o.write(frame)
+			frame.release()
+		}
+	}
+}
+
+func (o *outbox) write(frame outboundFrame) {
+	data, messageType := frame.data, websocket.BinaryMessage
+	if frame.ping {
+		messageType = websocket.PingMessage
+	}
+	if frame.envelope && len(data) > 0 {
+		o.sequence++
+		o.buffer = appendEnvelope(o.buffer[:0], data[0], o.sequence, data[1:])
+		data = o.buffer
+	}
+
+	// Only the payload is counted: flushed also reports the frame headers, so the count never stays above
+	// what the engine really buffers.
+	o.mu.Lock()
+	if o.tracked {
+		o.buffered += len(data)
+	}
+	o.mu.Unlock()
+	err := o.conn.WriteMessage(messageType, data)
+	if err != nil {
+		fmt.Println("Failed to send message to client:", err)
+	}
+	if frame.closeAfter {
+		o.conn.Close()
+	}
+}
+
+// idle reports whether every queued frame has been written.
+func (o *outbox) idle() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.frames) == 0 && !o.writing
+}
+
+// close stops the writer and drops the frames still queued.
+func (o *outbox) close() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed = true
+	for _, frame := range o.frames {
+		frame.release()
+	}
+	o.frames = nil
+	select {
+	case <-o.stop:
+	default:
+		close(o.stop)
+	}
+}
+
+// appendEnvelope appends the Envelope of a message to b. It encodes the same bytes as marshaling an
+// Envelope, without building one.
+func appendEnvelope(b []byte, messageType byte, sequence uint32, payload []byte) []byte {
+	b = protowire.AppendTag(b, envelopeTypeField, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(messageType))
+	b = protowire.AppendTag(b, envelopeSequenceField, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(sequence))
+	b = protowire.AppendTag(b, envelopePayloadField, protowire.BytesType)
+	return protowire.AppendBytes(b, payload)
+}
@@ -0,0 +1,208 @@
+package gameserver
+
+import (
+	"errors"
+	"net"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// blockingConn is a net.Conn for a client that reads nothing: every write blocks until the connection is
+// closed.
+type blockingConn struct {
+	recordingConn
+	blocked chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newBlockingConn() *blockingConn {
+	return &blockingConn{blocked: make(chan struct{}, 1), done: make(chan struct{})}
+}
+
+func (c *blockingConn) Write([]byte) (int, error) {
+	select {
+	case c.blocked <- struct{}{}:
+	default:
+	}
+	<-c.done
+	return 0, net.ErrClosed
+}
+
+func (c *blockingConn) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return c.recordingConn.Close()
+}
+
+// queued returns the first byte after the message type of every frame waiting in the outbox.
+func queued(o *outbox) []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var marks []byte
+	for _, frame := range o.frames {
+		marks = append(marks, frame.data[1])
+	}
+	return marks
+}
+
+func TestSlowClient(t *testing.T) {
+	s := New(Config{SendQueueSize: 4, SlowClientTimeout: 100 * time.Millisecond})
+	underlying := newBlockingConn()
+	c := websocket.NewServerConn(s.upgrader, underlying, "", false, false)
+	s.OnOpen(c)
+	out := sessionOf(c).out
+
+	now := time.Now()
+	if err := out.push(outboundFrame{data: []byte{REQUEST_SCOREBOARD, 0}}, now); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-underlying.blocked:
+	case <-time.After(time.Second):
+		t.Fatal("writer did not start writing")
+	}
+
+	// The writer is stuck, so snapshots pile up and the newest ones replace the oldest.
+	for i := byte(1); i <= 10; i++ {
+		if err := out.push(outboundFrame{data: []byte{UPDATE_LOCATION, i}}, now); err != nil {
+			t.Fatalf("snapshot %d was refused: %v", i, err)
+		}
+	}
+	if marks := queued(out); string(marks) != string([]byte{7, 8, 9, 10}) {
+		t.Fatalf("queued snapshots %v, want the newest 7 to 10", marks)
+	}
+
+	// The queue has been full since now, longer than SlowClientTimeout.
+	err := out.push(outboundFrame{data: []byte{UPDATE_LOCATION, 11}}, now.Add(200*time.Millisecond))
+	if !errors.Is(err, net.ErrClosed) {
+		t.Fatalf("slow client was not disconnected: %v", err)
+	}
+	if !underlying.isClosed() {
+		t.Fatal("slow client's connection was left open")
+	}
+}
+
+func TestSendBuffer(t *testing.T) {
+	s := New(Config{SendBufferSize: 10})
+	c, underlying := openTestConn(s)
+	out := sessionOf(c).out
+	out.track()
+
+	// settle waits for the writer to drain the queue or fill the buffer, then returns how many frames were written and how many are queued.
+	settle := func() (int, int) {
+		deadline := time.Now().Add(time.Second)
+		for {
+			out.mu.Lock()
+			stopped := !out.writing && (len(out.frames) == 0 || out.buffered >= out.bufferSize)
+			queued := len(out.frames)
+			out.mu.Unlock()
+			if stopped {
+				return len(underlying.messages(t)), queued
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("writer did not stop")
+			}
+			runtime.Gosched()
+		}
+	}
+
+	now := time.Now()
+	for i := byte(0); i < 5; i++ {
+		if err := out.push(outboundFrame{data: []byte{UPDATE_LOCATION, i, 0, 0, 0, 0, 0, 0}}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two frames of 8 bytes fill the 10 byte buffer until the engine reports them written.
+	if written, queued := settle(); written != 2 || queued != 3 {
+		t.Fatalf("wrote %d frames and queued %d, want 2 and 3", written, queued)
+	}
+	out.flushed(8)
+	if written, queued := settle(); written != 3 || queued != 2 {
+		t.Fatalf("wrote %d frames and queued %d after a flush, want 3 and 2", written, queued)
+	}
+	out.flushed(100)
+	if written, queued := settle(); written != 5 || queued != 0 {
+		t.Fatalf("wrote %d frames and queued %d after flushing everything, want 5 and 0", written, queued)
+	}
+}
+
+func TestFullQueueWithoutSnapshots(t *testing.T) {
+	s := New(Config{SendQueueSize: 2})
+	underlying := newBlockingConn()
+	c := websocket.NewServerConn(s.upgrader, underlying, "", false, false)
+	s.OnOpen(c)
+	out := sessionOf(c).out
+
+	now := time.Now()
+	if err := out.push(outboundFrame{data: []byte{REQUEST_SCOREBOARD, 0}}, now); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-underlying.blocked:
+	case <-time.After(time.Second):
+		t.Fatal("writer did not start writing")
+	}
+	for i := byte(1); i <= 2; i++ {
+		if err := out.push(outboundFrame{data: []byte{REQUEST_SCOREBOARD, i}}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Dropping the scoreboard would leave the client with a stale one, so the client is disconnected.
+	err := out.push(outboundFrame{data: []byte{REQUEST_SCOREBOARD, 3}}, now)
+	if !errors.Is(err, net.ErrClosed) || !underlying.isClosed() {
+		t.Fatalf("client with a queue full of scoreboards was not disconnected: %v", err)
+	}
+}
+
+func TestSlowClientCatchingUp(t *testing.T) {
+	// Frames of 2 bytes fill the buffer one at a time, so every flush lets the writer take one frame.
+	s := New(Config{SendQueueSize: 2, SendBufferSize: 2, SlowClientTimeout: 100 * time.Millisecond})
+	c, _ := openTestConn(s)
+	out := sessionOf(c).out
+	out.track()
+
+	// settle waits for the writer to stop at the buffer or an empty queue.
+	settle := func() {
+		deadline := time.Now().Add(time.Second)
+		for {
+			out.mu.Lock()
+			stopped := !out.writing && (len(out.frames) == 0 || out.buffered >= out.bufferSize)
+			out.mu.Unlock()
+			if stopped {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("writer did not stop")
+			}
+			runtime.Gosched()
+		}
+	}
+	push := func(mark byte, at time.Time) error {
+		err := out.push(outboundFrame{data: []byte{UPDATE_LOCATION, mark}}, at)
+		settle()
+		return err
+	}
+
+	now := time.Now()
+	for i := byte(0); i < 4; i++ {
+		if err := push(i, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The queue filled up at now, then the client took a frame without the queue ever draining.
+	out.flushed(2)
+	settle()
+	if err := push(4, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := push(5, now.Add(200*time.Millisecond)); err != nil {
+		t.Fatalf("client that took a frame from its full queue was disconnected: %v", err)
+	}
+	if marks := queued(out); string(marks) != string([]byte{4, 5}) {
+		t.Fatalf("queued snapshots %v, want 4 and 5", marks)
+	}
+}
@@ -0,0 +1,165 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// RegisterPlayer registers a new player in the room from the name and color of tempPlayer and returns
+// the marshaled player.
+func (r *Room) RegisterPlayer(tempPlayer *proto.Player, c *websocket.Conn) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.isFull() {
+		fmt.Printf("Room %d is full, rejecting registration\n", r.id)
+		return nil, ErrRoomFull
+	}
+
+	playerID := r.ids.Allocate()
+
+	p := &proto.Player{
+		Casting:      proto2.Bool(false),
+		CurrentSpell: proto2.Uint32(firstSpell(r.config.Spells)),
+		PlayerColor:  proto2.String(tempPlayer.GetPlayerColor()),
+		Name:         proto2.String(tempPlayer.GetName()),
+		Id:           proto2.Uint32(playerID),
+		RotationY:    proto2.Float32(0),
+		RotationX:    proto2.Float32(0),
+	}
+	r.assignTeam(p)
+	r.spawn(p)
+
+	byteSlice, protoErr := r.marshalPlayer(p)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling player at registration with ID %d: %v\n", playerID, protoErr)
+		r.ids.Release(playerID)
+		return nil, protoErr
+	}
+
+	newPlayerScore := &proto.Score{
+		Name:  tempPlayer.Name,
+		Id:    proto2.Uint32(playerID),
+		Score: proto2.Uint32(0),
+		Team:  p.Team,
+	}
+	r.addPlayer(p, newPlayerScore, c)
+
+	return byteSlice, nil
+}
+
+// UpdatePlayerLocation copies the position and rotation of a location update from the player into the
+// stored player, after checking it with validateLocation. Players that send INPUT are moved by the server
+// and their updates are ignored, as are those of dead players. It returns the POSITION_CORRECTION frame
+// to send back if the update was invalid, and whether the player reached Config.MaxMoveViolations and
+// should be kicked.
+func (r *Room) UpdatePlayerLocation(playerID uint32, p *proto.Player) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.movement[playerID]; ok {
+		return nil, false
+	}
+
+	value, ok := r.players.Load(playerID)
+	if !ok {
+		return nil, false
+	}
+	player := value.(*proto.Player)
+	if player.GetPlayerState() == proto.PLAYER_STATE_DEAD {
+		return nil, false
+	}
+
+	violation := false
+	if finite(p.GetRotationY(), p.GetRotationX()) {
+		player.RotationY = p.RotationY
+		player.RotationX = p.RotationX
+	} else {
+		violation = true
+	}
+	if len(p.GetPos()) > 0 {
+		pos, invalid := r.validateLocation(playerID, positionVec(p.GetPos()[0]), time.Now())
+		player.Pos = []*proto.Player_Position{pos.position()}
+		violation = violation || invalid
+	}
+
+	if !violation {
+		return nil, false
+	}
+	return r.correction(player), r.recordViolation(playerID)
+}
+
+// PollPlayers polls the players and marshals the data to be sent.
+func (r *Room) PollPlayers() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	playerSlice := make([]*proto.Player, 0)
+	r.players.Range(func(_, value interface{}) bool {
+		player := value.(*proto.Player)
+		playerSlice = append(playerSlice, player)
+		return true
+	})
+
+	byteSlice, protoErr := proto2.Marshal(&proto.Players{Player: playerSlice, Tick: r.stamp()})
+	if protoErr != nil {
+		fmt.Printf("Error marshaling Players: %v\n", protoErr)
+		return nil
+	}
+	return byteSlice
+}
+
+// PollPlayerLocations returns the marshaled player list sent in reply to POLL_LOCATIONS.
+func (r *Room) PollPlayerLocations() []byte {
+	return r.PollPlayers()
+}
+
+func (r *Room) disconnectedPlayerData(id uint32) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if player, ok := r.players.Load(id); ok {
+		byteSlice, protoErr := r.marshalPlayer(player.(*proto.Player))
+		if protoErr != nil {
+			fmt.Printf("Error marshaling disconnected player with ID %d: %v\n", id, protoErr)
+			return nil
+		}
+		return byteSlice
+	}
+	fmt.Printf("Player with ID %d not found\n", id)
+	return nil
+}
+
+// transferPlayer adds a player coming from another room into the slot held for them with reserve, keeping
+// their ID, name and color but spawning them again and resetting their score. It returns the marshaled player.
+func (r *Room) transferPlayer(p *proto.Player, c *websocket.Conn) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.members.Add(-1)
+
+	r.reserved--
+	r.assignTeam(p)
+	r.spawn(p)
+	// Casts and cooldowns are counted in the old room's ticks.
+	p.Casting = proto2.Bool(false)
+	p.ReadyTick = nil
+
+	newPlayerScore := &proto.Score{
+		Name:  p.Name,
+		Id:    proto2.Uint32(p.GetId()),
+		Score: proto2.Uint32(0),
+		Team:  p.Team,
+	}
+	r.addPlayer(p, newPlayerScore, c)
+
+	byteSlice, protoErr := r.marshalPlayer(p)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling transferred player with ID %d: %v\n", p.GetId(), protoErr)
+		return nil
+	}
+	return byteSlice
+}
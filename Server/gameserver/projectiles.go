@@ -0,0 +1,159 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// projectile is a spell in flight, simulated by the room's tick loop.
+type projectile struct {
+	id       uint32
+	casterID uint32
+	spell    uint32
+	position vec3
+	velocity vec3
+	radius   float32
+	damage   float32
+	expires  time.Time
+}
+
+func (p *projectile) message(tick uint64, targetID *uint32) *proto.Projectile {
+	return &proto.Projectile{
+		Id:       proto2.Uint32(p.id),
+		CasterId: proto2.Uint32(p.casterID),
+		Spell:    proto2.Uint32(p.spell),
+		Position: p.position.position(),
+		Velocity: p.velocity.position(),
+		TargetId: targetID,
+		Tick:     proto2.Uint64(tick),
+	}
+}
+
+// marshal marshals the projectile stamped with tick.
+func (p *projectile) marshal(tick uint64, targetID *uint32) []byte {
+	byteSlice, protoErr := proto2.Marshal(p.message(tick, targetID))
+	if protoErr != nil {
+		fmt.Printf("Error marshaling projectile with ID %d: %v\n", p.id, protoErr)
+		return nil
+	}
+	return byteSlice
+}
+
+// roomEvent is a message broadcast to the room once a simulation step is done.
+type roomEvent struct {
+	messageType byte
+	message     []byte
+}
+
+// spawnProjectile launches a spell from the caster's last known position in the direction they are
+// facing and returns the marshaled projectile for PROJECTILE_SPAWN. Callers must hold r.mu.
+func (r *Room) spawnProjectile(caster *proto.Player, spellID uint32, spell Spell) []byte {
+	p := &projectile{
+		id:       r.ids.Allocate(),
+		casterID: caster.GetId(),
+		spell:    spellID,
+		position: positionVec(caster.GetPos()[0]),
+		velocity: forward(caster.GetRotationY()).scale(spell.Speed),
+		radius:   spell.Radius,
+		damage:   spell.Damage,
+		expires:  time.Now().Add(spell.Lifetime),
+	}
+	r.projectiles[p.id] = p
+
+	return p.marshal(r.Tick(), nil)
+}
+
+// stepProjectiles advances every projectile by dt, resolving hits against player hitboxes and
+// expiring projectiles past their lifetime. It broadcasts the resulting events.
+func (r *Room) stepProjectiles(dt time.Duration, now time.Time) {
+	var events []roomEvent
+	killed := false
+
+	r.mu.Lock()
+	for id, p := range r.projectiles {
+		from := p.position
+		to := from.add(p.velocity.scale(float32(dt.Seconds())))
+		p.position = to
+
+		if target := r.projectileHit(p, from, to, r.viewTime(p.casterID, now)); target != nil {
+			delete(r.projectiles, id)
+			r.ids.Release(id)
+			events = append(events, roomEvent{PROJECTILE_HIT, p.marshal(r.Tick(), proto2.Uint32(target.GetId()))})
+
+			damaged, died := r.applyDamage(p.casterID, target, p.damage)
+			if damaged != nil {
+				events = append(events, roomEvent{DAMAGE_PLAYER, damaged})
+			}
+			killed = killed || died
+			continue
+		}
+
+		if now.After(p.expires) {
+			delete(r.projectiles, id)
+			r.ids.Release(id)
+			events = append(events, roomEvent{PROJECTILE_DESPAWN, p.marshal(r.Tick(), nil)})
+		}
+	}
+	r.mu.Unlock()
+
+	for _, event := range events {
+		if event.message != nil {
+			r.BroadcastMessage(event.messageType, event.message)
+		}
+	}
+	if killed {
+		r.BroadcastMessage(REQUEST_SCOREBOARD, r.ReturnScoreboard())
+	}
+}
+
+// clearProjectiles removes every projectile in flight and releases their IDs, for rooms that close.
+func (r *Room) clearProjectiles() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.projectiles {
+		delete(r.projectiles, id)
+		r.ids.Release(id)
+	}
+}
+
+// projectileHit returns the closest living player, other than the caster, whose hitbox the projectile
+// touched while moving from one position to the other. Targets are tested where the caster saw them
+// at viewTime. Callers must hold r.mu.
+func (r *Room) projectileHit(p *projectile, from, to vec3, viewTime time.Time) *proto.Player {
+	var hit *proto.Player
+	var hitDistance float32
+
+	r.players.Range(func(_, value interface{}) bool {
+		player := value.(*proto.Player)
+		if player.GetId() == p.casterID || player.GetPlayerState() == proto.PLAYER_STATE_DEAD || len(player.GetPos()) == 0 ||
+			!r.canDamage(p.casterID, player) {
+			return true
+		}
+
+		bottom, top, radius := r.rewoundCapsule(player, viewTime)
+		if segmentDistance(from, to, bottom, top) > radius+p.radius {
+			return true
+		}
+
+		d := positionVec(player.GetPos()[0]).sub(from).length()
+		if hit == nil || d < hitDistance {
+			hit = player
+			hitDistance = d
+		}
+		return true
+	})
+	return hit
+}
+
+// castData builds the INIT_CAST payload relayed to other clients so they can play the cast.
+func castData(casterID uint32) []byte {
+	byteSlice, protoErr := proto2.Marshal(&proto.Damage{CasterId: proto2.Uint32(casterID)})
+	if protoErr != nil {
+		fmt.Printf("Error marshaling cast of player with ID %d: %v\n", casterID, protoErr)
+		return nil
+	}
+	return byteSlice
+}
@@ -0,0 +1,99 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"testing"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestStepProjectiles(t *testing.T) {
+	s := New(Config{})
+	room := s.defaultRoom
+	c, underlying, casterID := registerPlayer(t, s, "Caster")
+	_, _, targetID := registerPlayer(t, s, "Target")
+	value, _ := room.players.Load(targetID)
+	target := value.(*proto.Player)
+	target.ProtectedTick = nil
+	target.Pos = []*proto.Player_Position{vec3{0, 1, 0}.position()}
+
+	tests := []struct {
+		name string
+		x    float32
+		// expiresIn is how long after the step the projectile expires.
+		expiresIn time.Duration
+		// event is the message the step broadcasts for the projectile, zero for none.
+		event byte
+	}{
+		{"hit", 0, time.Second, PROJECTILE_HIT},
+		{"miss", 5, time.Second, 0},
+		{"despawn", 5, -time.Millisecond, PROJECTILE_DESPAWN},
+		{"hit as it expires", 0, -time.Millisecond, PROJECTILE_HIT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			target.Health = proto2.Float32(100)
+			room.mu.Lock()
+			id := room.ids.Allocate()
+			room.projectiles[id] = &projectile{
+				id:       id,
+				casterID: casterID,
+				position: vec3{tt.x, 1, -1},
+				velocity: vec3{0, 0, 20},
+				radius:   0.1,
+				damage:   10,
+				expires:  now.Add(tt.expiresIn),
+			}
+			room.mu.Unlock()
+			waitSent(t, c)
+			seen := len(underlying.messages(t))
+
+			room.stepProjectiles(100*time.Millisecond, now)
+			waitSent(t, c)
+
+			var event byte
+			got := &proto.Projectile{}
+			for _, message := range underlying.messages(t)[seen:] {
+				if message[0] != PROJECTILE_HIT && message[0] != PROJECTILE_DESPAWN {
+					continue
+				}
+				event = message[0]
+				if err := proto2.Unmarshal(message[1:], got); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if event != tt.event {
+				t.Fatalf("step broadcast message type %d, want %d", event, tt.event)
+			}
+
+			room.mu.Lock()
+			p, inFlight := room.projectiles[id]
+			delete(room.projectiles, id)
+			room.mu.Unlock()
+			switch tt.event {
+			case 0:
+				if !inFlight || p.position != (vec3{tt.x, 1, 1}) {
+					t.Fatalf("projectile in flight: %v at %v, want it moved to z 1", inFlight, p)
+				}
+			case PROJECTILE_HIT:
+				if inFlight || got.GetId() != id || got.GetTargetId() != targetID {
+					t.Fatalf("projectile in flight: %v and hit %v, want %d to hit %d", inFlight, got, id, targetID)
+				}
+			case PROJECTILE_DESPAWN:
+				if inFlight || got.GetId() != id || got.TargetId != nil {
+					t.Fatalf("projectile in flight: %v and despawned as %v, want %d without a target", inFlight, got, id)
+				}
+			}
+
+			health := float32(100)
+			if tt.event == PROJECTILE_HIT {
+				health -= 10
+			}
+			if target.GetHealth() != health {
+				t.Fatalf("target health is %v, want %v", target.GetHealth(), health)
+			}
+		})
+	}
+}
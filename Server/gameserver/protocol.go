@@ -0,0 +1,137 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Protocol versions. Version 1 frames are a message type byte followed by the message, version 2 frames
+// are Envelope messages. Connections that never send HELLO speak version 1.
+const (
+	legacyProtocol   = 1
+	envelopeProtocol = 2
+
+	// ProtocolVersion is the newest protocol version the server speaks.
+	ProtocolVersion = envelopeProtocol
+)
+
+// writeFrame queues a marshaled message of the given type on the connection's outbox. The framing layer,
+// writeFrame, sendFrame and the room broadcasts, is the only place the type is added, so messages are
+// always passed without it. The frame is wrapped in an Envelope if the connection negotiated protocol
+// version 2 or later, and is the type byte followed by the message otherwise.
+func writeFrame(c *websocket.Conn, messageType byte, message []byte) error {
+	f := newFrame(messageType)
+	f.data = append(f.data, message...)
+	err := sendFrame(c, f)
+	f.release()
+	return err
+}
+
+// writeLegacyFrame queues a message in protocol version 1 framing, whatever the connection negotiated.
+// With closeAfter the connection is closed once the frame is written.
+func writeLegacyFrame(c *websocket.Conn, messageType byte, message []byte, closeAfter bool) error {
+	frame := append([]byte{messageType}, message...)
+	return sessionOf(c).out.push(outboundFrame{data: frame, closeAfter: closeAfter}, time.Now())
+}
+
+// readFrame splits an inbound frame into its message type and message, unwrapping the Envelope if the
+// connection negotiated protocol version 2 or later. Empty frames and envelopes that do not decode,
+// including those with an unknown type, are MALFORMED_FRAME errors, as are envelopes whose sequence is not
+// above that of the last one received: they are duplicates or replays, since WebSocket frames arrive in
+// order.
+func readFrame(c *websocket.Conn, frame []byte) (byte, []byte, *Error) {
+	sess := sessionOf(c)
+	if sess.protocolVersion() < envelopeProtocol {
+		if len(frame) == 0 {
+			return 0, nil, newError(proto.ErrorCode_MALFORMED_FRAME, "empty frame")
+		}
+		return frame[0], frame[1:], nil
+	}
+
+	envelope := proto.Envelope{}
+	err := proto2.Unmarshal(frame, &envelope)
+	if err != nil {
+		return 0, nil, newError(proto.ErrorCode_MALFORMED_FRAME, "malformed envelope: %v", err)
+	}
+	if envelope.Sequence != nil {
+		last, skipped, ok := sess.receivedSequence(envelope.GetSequence())
+		if !ok {
+			return 0, nil, newError(proto.ErrorCode_MALFORMED_FRAME, "envelope %d arrived after envelope %d",
+				envelope.GetSequence(), last)
+		}
+		if skipped > 0 {
+			fmt.Printf("%s skipped %d envelopes before envelope %d\n", c.RemoteAddr().String(), skipped, envelope.GetSequence())
+		}
+	}
+	return byte(envelope.GetType()), envelope.GetPayload(), nil
+}
+
+// hello handles the HELLO handshake. Clients asking for a newer protocol than the server speaks are
+// downgraded to ProtocolVersion, clients older than Config.MinProtocolVersion are rejected.
+func (s *GameServer) hello(data []byte, c *websocket.Conn) {
+	sess := sessionOf(c)
+	if sess.handshaken() {
+		fmt.Println("Ignoring repeated HELLO from", c.RemoteAddr().String())
+		return
+	}
+	if playerID, _ := sess.get(); playerID != 0 {
+		s.reject(c, "HELLO must be sent before REGISTER")
+		return
+	}
+
+	hello := proto.Hello{}
+	err := proto2.Unmarshal(data, &hello)
+	if err != nil {
+		s.reject(c, fmt.Sprintf("malformed HELLO: %v", err))
+		return
+	}
+
+	version := hello.GetProtocolVersion()
+	if version < s.config.MinProtocolVersion {
+		s.reject(c, fmt.Sprintf("protocol version %d is not supported, the server requires at least version %d",
+			version, s.config.MinProtocolVersion))
+		return
+	}
+	if version > ProtocolVersion {
+		version = ProtocolVersion
+	}
+
+	welcome := &proto.Welcome{ProtocolVersion: proto2.Uint32(version)}
+	if s.config.ServerVersion != "" {
+		welcome.ServerVersion = proto2.String(s.config.ServerVersion)
+	}
+	byteSlice, protoErr := proto2.Marshal(welcome)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling Welcome: %v\n", protoErr)
+		return
+	}
+	// The reply still uses the framing the client spoke HELLO in, the negotiated one applies after it.
+	err = writeLegacyFrame(c, HELLO, byteSlice, false)
+	if err != nil {
+		fmt.Println("HELLO error")
+		fmt.Println(err.Error())
+		return
+	}
+	sess.setProtocol(version)
+	fmt.Printf("%s speaks protocol version %d (build %q)\n", c.RemoteAddr().String(), version, hello.GetBuildVersion())
+}
+
+// reject answers HELLO with the reason the client cannot play and closes the connection.
+func (s *GameServer) reject(c *websocket.Conn, reason string) {
+	fmt.Printf("Rejecting %s: %s\n", c.RemoteAddr().String(), reason)
+	welcome := &proto.Welcome{
+		ProtocolVersion: proto2.Uint32(ProtocolVersion),
+		Error:           proto2.String(reason),
+	}
+	if s.config.ServerVersion != "" {
+		welcome.ServerVersion = proto2.String(s.config.ServerVersion)
+	}
+	byteSlice, protoErr := proto2.Marshal(welcome)
+	if protoErr != nil || writeLegacyFrame(c, HELLO, byteSlice, true) != nil {
+		c.Close()
+	}
+}
@@ -0,0 +1,110 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"testing"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestHello(t *testing.T) {
+	tests := []struct {
+		name       string
+		minVersion uint32
+		// frames are sent in order, nil standing for a REGISTER in protocol version 1 framing.
+		frames  [][]byte
+		want    uint32
+		refused bool
+	}{
+		{"legacy client", 0, [][]byte{nil}, legacyProtocol, false},
+		{"current client", 0, [][]byte{hello(ProtocolVersion)}, ProtocolVersion, false},
+		{"newer client is downgraded", 0, [][]byte{hello(ProtocolVersion + 3)}, ProtocolVersion, false},
+		{"older client", envelopeProtocol, [][]byte{hello(legacyProtocol)}, 0, true},
+		{"no HELLO", envelopeProtocol, [][]byte{nil}, 0, true},
+		{"malformed HELLO", 0, [][]byte{{HELLO, 0xff}}, 0, true},
+		{"HELLO after REGISTER", 0, [][]byte{nil, hello(ProtocolVersion)}, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := New(Config{MinProtocolVersion: test.minVersion})
+			c, underlying := openTestConn(s)
+			for _, f := range test.frames {
+				if f == nil {
+					f = frame(REGISTER, testPlayer("Client"))
+				}
+				s.OnMessage(c, websocket.BinaryMessage, f)
+			}
+			waitSent(t, c)
+
+			var welcome *proto.Welcome
+			for _, message := range underlying.messages(t) {
+				if message[0] == HELLO {
+					welcome = &proto.Welcome{}
+					if err := proto2.Unmarshal(message[1:], welcome); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if test.refused {
+				if welcome == nil || welcome.Error == nil || !underlying.isClosed() {
+					t.Fatalf("client was not refused, got %v", welcome)
+				}
+				return
+			}
+			if underlying.isClosed() {
+				t.Fatal("client was refused")
+			}
+			if got := sessionOf(c).protocolVersion(); got != test.want {
+				t.Fatalf("client speaks protocol version %d, want %d", got, test.want)
+			}
+			if welcome != nil && welcome.GetProtocolVersion() != test.want {
+				t.Fatalf("Welcome carries protocol version %d, want %d", welcome.GetProtocolVersion(), test.want)
+			}
+		})
+	}
+}
+
+func TestEnvelopeSequence(t *testing.T) {
+	// The first envelope is accepted whatever its sequence, so clients may count from 0.
+	for _, first := range []uint32{0, 1, 100} {
+		t.Run(fmt.Sprintf("from %d", first), func(t *testing.T) {
+			s := New(Config{})
+			c, underlying := openTestConn(s)
+			s.OnMessage(c, websocket.BinaryMessage, hello(ProtocolVersion))
+			for _, offset := range []uint32{0, 1, 1, 4, 2} {
+				envelope, err := proto2.Marshal(&proto.Envelope{
+					Type:     proto.MessageType_REQUEST_SCOREBOARD.Enum(),
+					Sequence: proto2.Uint32(first + offset),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				s.OnMessage(c, websocket.BinaryMessage, envelope)
+			}
+
+			waitSent(t, c)
+			scoreboards, malformed := 0, 0
+			for _, message := range underlying.messages(t)[1:] {
+				if code, ok := errorCode(message); ok && code == proto.ErrorCode_MALFORMED_FRAME {
+					malformed++
+					continue
+				}
+				envelope := proto.Envelope{}
+				if proto2.Unmarshal(message, &envelope) == nil && envelope.GetType() == proto.MessageType_REQUEST_SCOREBOARD {
+					scoreboards++
+				}
+			}
+			if scoreboards != 3 || malformed != 2 {
+				t.Fatalf("answered %d envelopes and rejected %d, want the 3 in order answered and the 2 late ones rejected",
+					scoreboards, malformed)
+			}
+		})
+	}
+}
+
+// hello returns a HELLO frame asking for the given protocol version.
+func hello(version uint32) []byte {
+	return frame(HELLO, &proto.Hello{ProtocolVersion: proto2.Uint32(version), BuildVersion: proto2.String("test")})
+}
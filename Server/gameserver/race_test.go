@@ -0,0 +1,6 @@
+//go:build race
+
+package gameserver
+
+// raceEnabled is set when the tests are built with -race.
+const raceEnabled = true
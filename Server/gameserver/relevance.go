@@ -0,0 +1,212 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+type cellKey struct {
+	x, z int32
+}
+
+// spatialGrid buckets players by their horizontal position into square cells as wide as the relevance
+// radius, so a relevance query only has to look at the 3x3 cells around the viewer.
+type spatialGrid struct {
+	cellSize float32
+	cells    map[cellKey][]uint32
+	// min and max bound the horizontal positions of the players, unplaced counts the players without one.
+	min, max vec3
+	unplaced int
+}
+
+func newSpatialGrid(world worldState, cellSize float32) *spatialGrid {
+	g := &spatialGrid{cellSize: cellSize, cells: make(map[cellKey][]uint32)}
+	first := true
+	for id, ps := range world {
+		if !ps.hasPos {
+			g.unplaced++
+			continue
+		}
+		key := g.key(ps.pos)
+		g.cells[key] = append(g.cells[key], id)
+
+		if first {
+			g.min, g.max = ps.pos, ps.pos
+			first = false
+		}
+		g.min.x = float32(math.Min(float64(g.min.x), float64(ps.pos.x)))
+		g.min.z = float32(math.Min(float64(g.min.z), float64(ps.pos.z)))
+		g.max.x = float32(math.Max(float64(g.max.x), float64(ps.pos.x)))
+		g.max.z = float32(math.Max(float64(g.max.z), float64(ps.pos.z)))
+	}
+	return g
+}
+
+// reachesAll reports whether every player is within radius of center, which is the case in arenas
+// smaller than the relevance radius.
+func (g *spatialGrid) reachesAll(center vec3, radius float32) bool {
+	if g.unplaced > 0 {
+		return false
+	}
+	dx := math.Max(math.Abs(float64(center.x-g.min.x)), math.Abs(float64(center.x-g.max.x)))
+	dz := math.Max(math.Abs(float64(center.z-g.min.z)), math.Abs(float64(center.z-g.max.z)))
+	return math.Hypot(dx, dz) <= float64(radius)
+}
+
+func (g *spatialGrid) key(pos vec3) cellKey {
+	return cellKey{
+		x: int32(math.Floor(float64(pos.x / g.cellSize))),
+		z: int32(math.Floor(float64(pos.z / g.cellSize))),
+	}
+}
+
+// within calls fn for every player whose horizontal distance to center is at most radius.
+func (g *spatialGrid) within(world worldState, center vec3, radius float32, fn func(id uint32)) {
+	c := g.key(center)
+	for x := c.x - 1; x <= c.x+1; x++ {
+		for z := c.z - 1; z <= c.z+1; z++ {
+			for _, id := range g.cells[cellKey{x, z}] {
+				d := world[id].pos.sub(center)
+				d.y = 0
+				if d.length() <= radius {
+					fn(id)
+				}
+			}
+		}
+	}
+}
+
+// alwaysRelevant reports whether other is sent to viewer regardless of distance: the viewer's own player
+// and, in team game modes, their teammates.
+func (r *Room) alwaysRelevant(world worldState, viewerID, otherID uint32) bool {
+	if viewerID == otherID {
+		return true
+	}
+	return r.teams() && world[viewerID].team != 0 && world[viewerID].team == world[otherID].team
+}
+
+// relevantWorld returns the part of world the viewer should receive: players within the relevance radius
+// and players that are always relevant to the viewer. Viewers without a position see everyone.
+func (r *Room) relevantWorld(world worldState, grid *spatialGrid, viewerID uint32) worldState {
+	viewer, ok := world[viewerID]
+	if !ok || !viewer.hasPos || grid.reachesAll(viewer.pos, r.config.RelevanceRadius) {
+		return world
+	}
+
+	visible := make(worldState)
+	grid.within(world, viewer.pos, r.config.RelevanceRadius, func(id uint32) {
+		visible[id] = world[id]
+	})
+	for id, ps := range world {
+		if _, ok := visible[id]; !ok && r.alwaysRelevant(world, viewerID, id) {
+			visible[id] = ps
+		}
+	}
+	return visible
+}
+
+// player converts replicated state back into a Player message.
+func (ps playerState) player(id uint32) *proto.Player {
+	state := ps.state
+	p := &proto.Player{
+		Name:         proto2.String(ps.name),
+		Id:           proto2.Uint32(id),
+		PlayerColor:  proto2.String(ps.color),
+		RotationY:    proto2.Float32(ps.rotY),
+		RotationX:    proto2.Float32(ps.rotX),
+		Health:       proto2.Float32(ps.health),
+		CurrentSpell: proto2.Uint32(ps.spell),
+		Casting:      proto2.Bool(ps.casting),
+		PlayerState:  &state,
+		Mana:         proto2.Float32(ps.mana),
+	}
+	if ps.hasPos {
+		p.Pos = []*proto.Player_Position{ps.pos.position()}
+	}
+	if ps.lastInput != 0 {
+		p.LastInput = proto2.Uint32(ps.lastInput)
+	}
+	if ps.readyTick != 0 {
+		p.ReadyTick = proto2.Uint64(ps.readyTick)
+	}
+	if ps.respawnTick != 0 {
+		p.RespawnTick = proto2.Uint64(ps.respawnTick)
+	}
+	if ps.protectedTick != 0 {
+		p.ProtectedTick = proto2.Uint64(ps.protectedTick)
+	}
+	if ps.team != 0 {
+		p.Team = proto2.Uint32(ps.team)
+	}
+	return p
+}
+
+// ids returns the IDs of the players in world in ascending order.
+func (world worldState) ids() []uint32 {
+	ids := make([]uint32, 0, len(world))
+	for id := range world {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// updateRelevance replaces the set of players the connection is receiving, given as ascending IDs, and
+// returns the players that entered and the ones that left it. The IDs are kept, so the caller must not
+// modify them afterwards.
+func (s *session) updateRelevance(ids []uint32) ([]uint32, []uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entered, left []uint32
+	i, j := 0, 0
+	for i < len(ids) || j < len(s.relevant) {
+		switch {
+		case j == len(s.relevant) || (i < len(ids) && ids[i] < s.relevant[j]):
+			entered = append(entered, ids[i])
+			i++
+		case i == len(ids) || s.relevant[j] < ids[i]:
+			left = append(left, s.relevant[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	s.relevant = ids
+	return entered, left
+}
+
+// sendRelevanceChanges tells the connection which players entered and left its relevance set. Players
+// that left the room are skipped, their clients already got PLAYER_DISCONNECT.
+func sendRelevanceChanges(conn *websocket.Conn, frames *snapshotFrames, entered, left []uint32) {
+	for _, change := range []struct {
+		messageType byte
+		ids         []uint32
+	}{{RELEVANCE_ENTER, entered}, {RELEVANCE_LEAVE, left}} {
+		ids := change.ids[:0]
+		for _, id := range change.ids {
+			if _, ok := frames.world[id]; ok {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		frame, err := frames.playerList(change.messageType, ids)
+		if err != nil {
+			fmt.Printf("Error marshaling relevance change: %v\n", err)
+			continue
+		}
+		err = sendFrame(conn, frame)
+		frame.release()
+		if err != nil {
+			fmt.Println("Failed to send message to client:", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"slices"
+	"testing"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// TestSpatialGrid checks that grid queries near and across cell boundaries find exactly the players a
+// scan of the whole world finds.
+func TestSpatialGrid(t *testing.T) {
+	const radius = 10
+	world := make(worldState)
+	id := uint32(1)
+	for _, x := range []float32{-20.5, -10, -9.9, -0.1, 0, 0.1, 9.9, 10, 10.1, 19.9, 20, 29.9} {
+		for _, z := range []float32{-10.1, 0, 9.9} {
+			world[id] = playerState{hasPos: true, pos: vec3{x, float32(id % 3 * 20), z}}
+			id++
+		}
+	}
+	grid := newSpatialGrid(world, radius)
+
+	for _, center := range []vec3{{0, 0, 0}, {9.99, 0, 0}, {10, 0, 0}, {-0.01, 0, 9.9}, {-10, 40, -10}, {25, 0, 5}} {
+		var got, want []uint32
+		grid.within(world, center, radius, func(id uint32) { got = append(got, id) })
+		for id, ps := range world {
+			d := ps.pos.sub(center)
+			d.y = 0
+			if d.length() <= radius {
+				want = append(want, id)
+			}
+		}
+		slices.Sort(got)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Fatalf("players within %v of %v are %v, want %v", radius, center, got, want)
+		}
+	}
+}
+
+func TestRelevance(t *testing.T) {
+	s := New(Config{RelevanceRadius: 10})
+	room := s.defaultRoom
+	c, underlying, viewerID := registerPlayer(t, s, "Viewer")
+	_, _, otherID := registerPlayer(t, s, "Other")
+
+	waitSent(t, c)
+	seen := len(underlying.messages(t))
+	// step moves the other player to x, runs a snapshot tick and returns the frames the viewer got.
+	step := func(x float32) map[byte][]uint32 {
+		t.Helper()
+		room.mu.Lock()
+		for id, x := range map[uint32]float32{viewerID: 0, otherID: x} {
+			value, _ := room.players.Load(id)
+			value.(*proto.Player).Pos = []*proto.Player_Position{vec3{x, 1, 0}.position()}
+		}
+		room.mu.Unlock()
+
+		room.sendSnapshots()
+		waitSent(t, c)
+		messages := underlying.messages(t)
+		frames := make(map[byte][]uint32)
+		for _, message := range messages[seen:] {
+			players := &proto.Players{}
+			if err := proto2.Unmarshal(message[1:], players); err != nil {
+				t.Fatal(err)
+			}
+			ids := []uint32{}
+			for _, p := range players.GetPlayer() {
+				ids = append(ids, p.GetId())
+			}
+			frames[message[0]] = ids
+		}
+		seen = len(messages)
+		return frames
+	}
+
+	tests := []struct {
+		name                   string
+		x                      float32
+		snapshot, enter, leave []uint32
+	}{
+		{"out of range", 50, []uint32{viewerID}, []uint32{viewerID}, nil},
+		{"still out of range", 45, []uint32{viewerID}, nil, nil},
+		{"entering from a cell away", 9.5, []uint32{viewerID, otherID}, []uint32{otherID}, nil},
+		{"moving across a cell boundary in range", -9.5, []uint32{viewerID, otherID}, nil, nil},
+		{"leaving within the next cell", -10.5, []uint32{viewerID}, nil, []uint32{otherID}},
+		{"entering at the radius", 10, []uint32{viewerID, otherID}, []uint32{otherID}, nil},
+		{"leaving two cells away", 25, []uint32{viewerID}, nil, []uint32{otherID}},
+	}
+	for _, tt := range tests {
+		// Players lists are in ascending ID order.
+		slices.Sort(tt.snapshot)
+		frames := step(tt.x)
+		if !slices.Equal(frames[UPDATE_LOCATION], tt.snapshot) || !slices.Equal(frames[RELEVANCE_ENTER], tt.enter) ||
+			!slices.Equal(frames[RELEVANCE_LEAVE], tt.leave) {
+			t.Fatalf("%s: viewer got snapshot %v, entering %v and leaving %v, want %v, %v and %v", tt.name,
+				frames[UPDATE_LOCATION], frames[RELEVANCE_ENTER], frames[RELEVANCE_LEAVE], tt.snapshot, tt.enter, tt.leave)
+		}
+	}
+}
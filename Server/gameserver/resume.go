@@ -0,0 +1,221 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// resumable is a registered player that a new connection can take over with its resume token.
+type resumable struct {
+	playerID uint32
+	// conn is the player's connection, or nil while the player is parked waiting for the client to come
+	// back. room is the room a parked player waits in, and timer removes it once the grace period is over.
+	conn  *websocket.Conn
+	room  *Room
+	timer *time.Timer
+	// offenses are those of the parked player's closed connection, handed to the one that resumes it.
+	offenses offenses
+}
+
+func newResumeToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// issueResumeToken sends the connection a new token that resumes its player, replacing the previous one.
+func (s *GameServer) issueResumeToken(c *websocket.Conn, playerID uint32) {
+	sess := sessionOf(c)
+	token := newResumeToken()
+
+	s.resumeMu.Lock()
+	if id, _ := sess.get(); id != playerID {
+		// The connection closed or lost its player in the meantime.
+		s.resumeMu.Unlock()
+		return
+	}
+	delete(s.resumes, sess.takeResumeToken())
+	s.resumes[token] = &resumable{playerID: playerID, conn: c}
+	sess.setResumeToken(token)
+	s.resumeMu.Unlock()
+
+	byteSlice, protoErr := proto2.Marshal(&proto.Resume{Token: proto2.String(token)})
+	if protoErr != nil {
+		fmt.Printf("Error marshaling Resume: %v\n", protoErr)
+		return
+	}
+	err := writeFrame(c, RESUME, byteSlice)
+	if err != nil {
+		fmt.Println("RESUME error")
+		fmt.Println(err.Error())
+	}
+}
+
+// detach unbinds a closing connection from its player and room, which it returns. Players with a resume
+// token are parked, unless they were kicked: they stay in the room, frozen and without a connection, for
+// Config.ResumeGracePeriod. It reports whether the player was parked.
+func (s *GameServer) detach(c *websocket.Conn) (uint32, *Room, bool) {
+	sess := sessionOf(c)
+
+	s.resumeMu.Lock()
+	defer s.resumeMu.Unlock()
+
+	playerID, room := sess.get()
+	token := sess.takeResumeToken()
+	sess.set(0, nil)
+
+	parked, ok := s.resumes[token]
+	if playerID == 0 || !ok || sess.wasKicked() {
+		delete(s.resumes, token)
+		return playerID, room, false
+	}
+
+	room.mu.Lock()
+	room.conns.Delete(playerID)
+	room.mu.Unlock()
+	// The parked player keeps the room open.
+	room.members.Add(1)
+
+	parked.conn = nil
+	parked.room = room
+	parked.offenses = sess.offenseCounts()
+	parked.timer = time.AfterFunc(s.config.ResumeGracePeriod, func() {
+		s.expireResume(token, parked)
+	})
+	fmt.Printf("Player %d disconnected, holding it for %v\n", playerID, s.config.ResumeGracePeriod)
+	return playerID, room, true
+}
+
+// expireResume removes a parked player whose grace period ran out without it being resumed.
+func (s *GameServer) expireResume(token string, parked *resumable) {
+	s.resumeMu.Lock()
+	if s.resumes[token] != parked || parked.conn != nil {
+		s.resumeMu.Unlock()
+		return
+	}
+	delete(s.resumes, token)
+	s.resumeMu.Unlock()
+
+	fmt.Printf("Player %d did not resume in time\n", parked.playerID)
+	parked.room.removePlayer(parked.playerID)
+	s.ids.Release(parked.playerID)
+	parked.room.members.Add(-1)
+	s.removeRoomIfEmpty(parked.room)
+}
+
+// offensesOf returns the offenses of a player, whether connected or parked.
+func (s *GameServer) offensesOf(playerID uint32) offenses {
+	var counts offenses
+	found := false
+	s.rooms.Range(func(_, value interface{}) bool {
+		if c, ok := value.(*Room).conns.Load(playerID); ok {
+			counts, found = sessionOf(c.(*websocket.Conn)).offenseCounts(), true
+		}
+		return !found
+	})
+	if found {
+		return counts
+	}
+
+	s.resumeMu.Lock()
+	defer s.resumeMu.Unlock()
+	for _, parked := range s.resumes {
+		if parked.playerID == playerID && parked.conn == nil {
+			return parked.offenses
+		}
+	}
+	return counts
+}
+
+// resume binds the connection to the player the token belongs to, either parked or still bound to a
+// connection that has not been noticed to drop yet, which is then closed. The client is sent its player,
+// a new token, and the players and scoreboard of the room.
+func (s *GameServer) resume(c *websocket.Conn, token string) error {
+	sess := sessionOf(c)
+
+	s.resumeMu.Lock()
+	if playerID, _ := sess.get(); playerID != 0 {
+		s.resumeMu.Unlock()
+		return ErrAlreadyRegistered
+	}
+	target, ok := s.resumes[token]
+	if !ok {
+		s.resumeMu.Unlock()
+		return ErrResumeFailed
+	}
+	delete(s.resumes, token)
+
+	room, old, counts := target.room, target.conn, target.offenses
+	if old != nil {
+		oldSess := sessionOf(old)
+		_, room = oldSess.get()
+		counts = oldSess.offenseCounts()
+		// Hold the room open while the player has no connection, like a parked player does.
+		room.members.Add(1)
+		oldSess.takeResumeToken()
+		oldSess.set(0, s.defaultRoom)
+	} else {
+		target.timer.Stop()
+	}
+	s.resumeMu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+
+	_, current := sess.get()
+	sess.setOffenses(counts)
+	registered := room.resumePlayer(target.playerID, c)
+	room.members.Add(-1)
+	if registered == nil {
+		s.ids.Release(target.playerID)
+		s.removeRoomIfEmpty(room)
+		return ErrResumeFailed
+	}
+	s.removeRoomIfEmpty(current)
+	fmt.Printf("Player %d resumed in room %d\n", target.playerID, room.id)
+
+	err := writeFrame(c, REGISTER, registered)
+	s.issueResumeToken(c, target.playerID)
+	if writeErr := writeFrame(c, REQUEST_PLAYERS, room.PollPlayers()); err == nil {
+		err = writeErr
+	}
+	if writeErr := writeFrame(c, REQUEST_SCOREBOARD, room.ReturnScoreboard()); err == nil {
+		err = writeErr
+	}
+	if writeErr := writeFrame(c, MATCH_STATE, room.MatchState()); err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+// resumePlayer binds a new connection to a player that is still in the room and returns the marshaled
+// player, or nil if the player is gone.
+func (r *Room) resumePlayer(id uint32, c *websocket.Conn) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.players.Load(id)
+	if !ok {
+		return nil
+	}
+	byteSlice, protoErr := r.marshalPlayer(value.(*proto.Player))
+	if protoErr != nil {
+		fmt.Printf("Error marshaling resumed player with ID %d: %v\n", id, protoErr)
+		return nil
+	}
+	r.conns.Store(id, c)
+	delete(r.afk, id)
+	if sess := sessionOf(c); sess != nil {
+		sess.set(id, r)
+	}
+	return byteSlice
+}
@@ -0,0 +1,211 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Room is an isolated match instance with its own players, scoreboard, tick loop and broadcast set.
+//
+// Lock discipline: players, scoreboard and conns can be looked up and ranged over without a lock, but
+// the *proto.Player and *proto.Score values in them are shared with the tick loop and may only be read,
+// modified or marshaled with mu held. Players and scores are added and removed with mu held too, so a
+// player found under mu stays in the room until mu is released. mu is never held while sending.
+type Room struct {
+	id         uint32
+	name       string
+	maxPlayers int
+	config     Config
+	ids        *IDAllocator
+
+	players    sync.Map
+	scoreboard sync.Map
+	conns      sync.Map
+	mu         sync.Mutex
+
+	// projectiles holds the spells in flight, guarded by mu.
+	projectiles map[uint32]*projectile
+	// tick is the number of simulation steps run so far, stamped into every outbound state message.
+	tick atomic.Uint64
+	// history holds the recent hitboxes of every player for lag compensation, guarded by mu.
+	history map[uint32]*positionHistory
+	// movement holds the input-driven movement state of players that send INPUT, guarded by mu.
+	movement map[uint32]*movement
+	// locations holds the last accepted client-reported position of every player, guarded by mu.
+	locations map[uint32]*locationCheck
+	// afk holds when every connected player last moved, looked around or cast, guarded by mu.
+	afk map[uint32]*afkCheck
+	// spellbooks holds the casts in progress and spell cooldowns of every player, guarded by mu.
+	spellbooks map[uint32]*spellbook
+	// match is the state of the match played in the room, guarded by mu.
+	match match
+	// teamScore holds the kills of every team in team game modes, indexed by team, guarded by mu.
+	teamScore []uint32
+	// reserved counts the slots held for players moving in from other rooms, guarded by mu.
+	reserved int
+
+	// members counts the connections whose session points at this room, registered or not, and the
+	// players waiting in it to be resumed.
+	members atomic.Int32
+	// sessions holds the session of every connection pointing at this room, registered or not, see
+	// session.set.
+	sessions sync.Map
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newRoom(id uint32, name string, maxPlayers int, config Config, ids *IDAllocator) *Room {
+	return &Room{
+		id:         id,
+		name:       name,
+		maxPlayers: maxPlayers,
+		config:     config,
+		ids:        ids,
+
+		projectiles: make(map[uint32]*projectile),
+		history:     make(map[uint32]*positionHistory),
+		movement:    make(map[uint32]*movement),
+		locations:   make(map[uint32]*locationCheck),
+		afk:         make(map[uint32]*afkCheck),
+		spellbooks:  make(map[uint32]*spellbook),
+		teamScore:   make([]uint32, config.Teams+1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// ID returns the room ID clients use in JOIN_ROOM.
+func (r *Room) ID() uint32 {
+	return r.id
+}
+
+// Name returns the display name of the room.
+func (r *Room) Name() string {
+	return r.name
+}
+
+// PlayerCount returns the number of players currently in the room.
+func (r *Room) PlayerCount() int {
+	count := 0
+	r.players.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// connectedCount returns the number of players in the room that have a connection, leaving out those
+// parked for a resume.
+func (r *Room) connectedCount() int {
+	count := 0
+	r.conns.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// isFull reports whether every slot of the room is taken or reserved. Callers must hold r.mu.
+func (r *Room) isFull() bool {
+	return r.maxPlayers > 0 && r.PlayerCount()+r.reserved >= r.maxPlayers
+}
+
+// full reports whether every slot of the room is taken or reserved.
+func (r *Room) full() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.isFull()
+}
+
+// reserve holds a slot for a player moving into the room until transferPlayer or unreserve, reporting
+// false if the room is full. The reservation also keeps the room from being removed as empty.
+func (r *Room) reserve() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.isFull() {
+		return false
+	}
+	r.reserved++
+	r.members.Add(1)
+	return true
+}
+
+// unreserve gives back a slot held with reserve.
+func (r *Room) unreserve() {
+	r.mu.Lock()
+	r.reserved--
+	r.mu.Unlock()
+	r.members.Add(-1)
+}
+
+func (r *Room) info() *proto.Room {
+	return &proto.Room{
+		Id:          proto2.Uint32(r.id),
+		Name:        proto2.String(r.name),
+		PlayerCount: proto2.Uint32(uint32(r.PlayerCount())),
+		MaxPlayers:  proto2.Uint32(uint32(r.maxPlayers)),
+	}
+}
+
+func (r *Room) start() {
+	go r.run()
+}
+
+func (r *Room) shutdown() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+}
+
+// addPlayer stores an already built player in the room and binds the connection's session to it.
+// Callers must hold r.mu.
+func (r *Room) addPlayer(p *proto.Player, score *proto.Score, c *websocket.Conn) {
+	r.resetLocationCheck(p.GetId(), positionVec(p.GetPos()[0]), time.Now())
+	r.players.Store(p.GetId(), p)
+	r.conns.Store(p.GetId(), c)
+	r.scoreboard.Store(p.GetId(), score)
+	if sess := sessionOf(c); sess != nil {
+		sess.set(p.GetId(), r)
+	}
+}
+
+// removePlayer removes a player from the room, notifies the remaining clients and returns the removed player.
+func (r *Room) removePlayer(id uint32) *proto.Player {
+	r.BroadcastPlayerData(PLAYER_DISCONNECT, r.disconnectedPlayerData(id), id)
+	r.mu.Lock()
+	value, ok := r.players.LoadAndDelete(id)
+	r.scoreboard.Delete(id)
+	r.conns.Delete(id)
+	delete(r.history, id)
+	delete(r.movement, id)
+	delete(r.locations, id)
+	delete(r.afk, id)
+	delete(r.spellbooks, id)
+	r.mu.Unlock()
+	r.BroadcastMessage(REQUEST_SCOREBOARD, r.ReturnScoreboard())
+	if !ok {
+		return nil
+	}
+	return value.(*proto.Player)
+}
+
+// BroadcastPlayerData sends a message to every connection in the room except the one owned by player id.
+func (r *Room) BroadcastPlayerData(messageType byte, message []byte, id uint32) {
+	f := newFrame(messageType)
+	f.data = append(f.data, message...)
+	r.broadcastFrame(f, id)
+	f.release()
+}
+
+// BroadcastMessage sends a message to every connection in the room.
+func (r *Room) BroadcastMessage(messageType byte, message []byte) {
+	r.BroadcastPlayerData(messageType, message, 0)
+}
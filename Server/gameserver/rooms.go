@@ -0,0 +1,203 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// newRoom creates a room and registers it with the server, starting its tick loop if the server is running.
+// Rooms created without a name are called "Room <id>".
+func (s *GameServer) newRoom(name string, maxPlayers int) *Room {
+	s.roomsMu.Lock()
+	defer s.roomsMu.Unlock()
+	return s.addRoom(name, maxPlayers)
+}
+
+// addRoom is newRoom for callers that hold s.roomsMu.
+func (s *GameServer) addRoom(name string, maxPlayers int) *Room {
+	s.nextRoomID++
+	if name == "" {
+		name = fmt.Sprintf("Room %d", s.nextRoomID)
+	}
+	room := newRoom(s.nextRoomID, name, maxPlayers, s.config, s.ids)
+	s.rooms.Store(room.id, room)
+	if s.running {
+		room.start()
+	}
+	return room
+}
+
+// Room returns the room with the given ID.
+func (s *GameServer) Room(id uint32) (*Room, bool) {
+	value, ok := s.rooms.Load(id)
+	if !ok {
+		return nil, false
+	}
+	return value.(*Room), true
+}
+
+// IDs returns the allocator the server's player and projectile IDs come from, so other entity types
+// can draw IDs that never collide with them.
+func (s *GameServer) IDs() *IDAllocator {
+	return s.ids
+}
+
+// DefaultRoom returns the room connections are placed in when they open.
+func (s *GameServer) DefaultRoom() *Room {
+	return s.defaultRoom
+}
+
+func (s *GameServer) roomCount() int {
+	count := 0
+	s.rooms.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// hasRoom reports whether room is still registered. Callers must hold s.roomsMu.
+func (s *GameServer) hasRoom(room *Room) bool {
+	value, ok := s.rooms.Load(room.id)
+	return ok && value == room
+}
+
+// removeRoomIfEmpty stops and forgets a room once its last connection left. The default room is never removed.
+func (s *GameServer) removeRoomIfEmpty(room *Room) {
+	if room == s.defaultRoom {
+		return
+	}
+
+	s.roomsMu.Lock()
+	defer s.roomsMu.Unlock()
+
+	if room.members.Load() > 0 {
+		return
+	}
+	s.rooms.Delete(room.id)
+	room.shutdown()
+}
+
+// listRooms marshals every open room.
+func (s *GameServer) listRooms() []byte {
+	rooms := proto.Rooms{}
+	s.rooms.Range(func(_, value interface{}) bool {
+		rooms.Room = append(rooms.Room, value.(*Room).info())
+		return true
+	})
+	byteSlice, protoErr := proto2.Marshal(&rooms)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling Rooms: %v\n", protoErr)
+		return nil
+	}
+	return byteSlice
+}
+
+// createRoom creates a room from a CREATE_ROOM request and moves the requesting connection into it.
+func (s *GameServer) createRoom(request *proto.Room, c *websocket.Conn) error {
+	maxPlayers := int(request.GetMaxPlayers())
+	if maxPlayers <= 0 || maxPlayers > s.config.MaxPlayersPerRoom {
+		maxPlayers = s.config.MaxPlayersPerRoom
+	}
+
+	s.roomsMu.Lock()
+	if s.roomCount() >= s.config.MaxRooms {
+		s.roomsMu.Unlock()
+		fmt.Println("Room limit reached, rejecting CREATE_ROOM")
+		return newError(proto.ErrorCode_ROOM_LIMIT_REACHED, "the server has reached its limit of %d rooms", s.config.MaxRooms)
+	}
+	room := s.addRoom(request.GetName(), maxPlayers)
+	s.roomsMu.Unlock()
+	return s.joinRoom(room, c)
+}
+
+// joinRoom moves the connection into room. Unregistered connections only switch the room they will
+// register into; registered players are removed from their current room and respawned in the new one,
+// whose slot is reserved first so they stay where they are if it is full. A room removed while the
+// connection was on its way in is not joined.
+func (s *GameServer) joinRoom(room *Room, c *websocket.Conn) error {
+	sess := sessionOf(c)
+	playerID, current := sess.get()
+
+	if current != room {
+		if playerID == 0 {
+			if room.full() {
+				fmt.Printf("Room %d is full, rejecting JOIN_ROOM\n", room.id)
+				return ErrRoomFull
+			}
+			// Holding s.roomsMu keeps removeRoomIfEmpty from removing the room before the session counts
+			// as one of its members.
+			s.roomsMu.Lock()
+			found := s.hasRoom(room)
+			if found {
+				sess.set(0, room)
+			}
+			s.roomsMu.Unlock()
+			if !found {
+				return errRoomGone(room)
+			}
+			s.removeRoomIfEmpty(current)
+		} else {
+			if !room.reserve() {
+				fmt.Printf("Room %d is full, rejecting JOIN_ROOM\n", room.id)
+				return ErrRoomFull
+			}
+			// The reserved slot counts as a member, so the room cannot be removed after this check.
+			s.roomsMu.Lock()
+			found := s.hasRoom(room)
+			s.roomsMu.Unlock()
+			if !found {
+				room.unreserve()
+				return errRoomGone(room)
+			}
+			player := current.removePlayer(playerID)
+			if player == nil {
+				room.unreserve()
+				s.removeRoomIfEmpty(room)
+				return nil
+			}
+
+			registered := room.transferPlayer(player, c)
+			s.removeRoomIfEmpty(current)
+
+			err := writeFrame(c, REGISTER, registered)
+			if err != nil {
+				fmt.Println("JOIN_ROOM error")
+				fmt.Println(err.Error())
+			}
+			room.BroadcastPlayerData(REQUEST_PLAYERS, room.PollPlayers(), playerID)
+			room.BroadcastMessage(REQUEST_SCOREBOARD, room.ReturnScoreboard())
+			err = writeFrame(c, MATCH_STATE, room.MatchState())
+			if err != nil {
+				fmt.Println("MATCH_STATE error")
+				fmt.Println(err.Error())
+			}
+		}
+	}
+
+	byteSlice, protoErr := proto2.Marshal(room.info())
+	if protoErr != nil {
+		fmt.Printf("Error marshaling room %d: %v\n", room.id, protoErr)
+		return protoErr
+	}
+	return writeFrame(c, JOIN_ROOM, byteSlice)
+}
+
+// joinRoomRequest moves the connection into the room requested with JOIN_ROOM.
+func (s *GameServer) joinRoomRequest(request *proto.Room, c *websocket.Conn) error {
+	room, ok := s.Room(request.GetId())
+	if !ok {
+		fmt.Printf("Room with ID %d not found\n", request.GetId())
+		return newError(proto.ErrorCode_ROOM_NOT_FOUND, "room %d not found", request.GetId())
+	}
+	return s.joinRoom(room, c)
+}
+
+// errRoomGone is the error for joining a room that was removed after it was looked up.
+func errRoomGone(room *Room) error {
+	fmt.Printf("Room %d was removed, rejecting JOIN_ROOM\n", room.id)
+	return newError(proto.ErrorCode_ROOM_NOT_FOUND, "room %d not found", room.id)
+}
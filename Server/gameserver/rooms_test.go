@@ -0,0 +1,105 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"sync"
+	"testing"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestJoinFullRoom(t *testing.T) {
+	s := New(Config{MaxPlayersPerRoom: 1})
+	registerPlayer(t, s, "Default")
+	join := func(c *websocket.Conn, room *Room) {
+		s.OnMessage(c, websocket.BinaryMessage, frame(JOIN_ROOM, &proto.Room{Id: proto2.Uint32(room.ID())}))
+	}
+	createRoom := func(name string) (*websocket.Conn, *recordingConn, *Room) {
+		c, underlying := openTestConn(s)
+		s.OnMessage(c, websocket.BinaryMessage, frame(CREATE_ROOM, &proto.Room{Name: proto2.String(name)}))
+		s.OnMessage(c, websocket.BinaryMessage, frame(REGISTER, testPlayer(name)))
+		_, room := sessionOf(c).get()
+		return c, underlying, room
+	}
+
+	_, _, full := createRoom("Full")
+	c, underlying, own := createRoom("Own")
+	playerID, _ := sessionOf(c).get()
+	join(c, full)
+	waitSent(t, c)
+
+	rejected := false
+	for _, message := range underlying.messages(t) {
+		if code, ok := errorCode(message); ok && code == proto.ErrorCode_ROOM_FULL {
+			rejected = true
+		}
+	}
+	if !rejected {
+		t.Fatal("joining a full room was not rejected with ROOM_FULL")
+	}
+	if id, room := sessionOf(c).get(); id != playerID || room != own || own.PlayerCount() != 1 {
+		t.Fatalf("player %d left their room after joining a full room, now player %d in room %d", playerID, id, room.ID())
+	}
+	if _, ok := s.Room(own.ID()); !ok || full.PlayerCount() != 1 || full.reserved != 0 {
+		t.Fatal("the rooms were changed by the rejected JOIN_ROOM")
+	}
+
+	// Players racing for the last slot of a room cannot overfill it.
+	open := s.newRoom("Open", 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		c, _, _ := createRoom("Racer")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			join(c, open)
+		}()
+	}
+	wg.Wait()
+	if count := open.PlayerCount(); count != 2 || open.reserved != 0 {
+		t.Fatalf("%d players and %d reservations in a room for 2", count, open.reserved)
+	}
+}
+
+func TestCreateRoomLimit(t *testing.T) {
+	// The default room counts toward MaxRooms.
+	s := New(Config{MaxRooms: 3})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		c, _ := openTestConn(s)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.OnMessage(c, websocket.BinaryMessage, frame(CREATE_ROOM, &proto.Room{}))
+		}()
+	}
+	wg.Wait()
+	if count := s.roomCount(); count != 3 {
+		t.Fatalf("%d rooms after racing CREATE_ROOMs, want 3", count)
+	}
+}
+
+func TestJoinRemovedRoom(t *testing.T) {
+	s := New(Config{})
+	// The room was looked up before its last connection left and it was removed.
+	gone := s.newRoom("Gone", 4)
+	s.removeRoomIfEmpty(gone)
+
+	unregistered, _ := openTestConn(s)
+	registered, _, playerID := registerPlayer(t, s, "Joiner")
+	for _, c := range []*websocket.Conn{unregistered, registered} {
+		before, _ := sessionOf(c).get()
+		err := s.joinRoom(gone, c)
+		if err == nil || err.(*Error).Code != proto.ErrorCode_ROOM_NOT_FOUND {
+			t.Fatalf("joining a removed room returned %v, want ROOM_NOT_FOUND", err)
+		}
+		if id, room := sessionOf(c).get(); id != before || room != s.defaultRoom {
+			t.Fatalf("player %d moved to room %d after joining a removed room", id, room.ID())
+		}
+	}
+	if gone.members.Load() != 0 || gone.reserved != 0 || s.defaultRoom.PlayerCount() != 1 {
+		t.Fatalf("removed room has %d members and %d reservations, player %d left the default room",
+			gone.members.Load(), gone.reserved, playerID)
+	}
+}
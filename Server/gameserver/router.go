@@ -0,0 +1,133 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// Context describes an inbound message to its handler.
+type Context struct {
+	Server *GameServer
+	Conn   *websocket.Conn
+	// Type is the message type the frame was sent with.
+	Type byte
+	// PlayerID is the sender's player, zero if the connection has not registered.
+	PlayerID uint32
+	// Room is the room the connection is in.
+	Room *Room
+	// Data is the encoded message.
+	Data []byte
+	// Message is Data decoded into the message type the handler was registered with, nil if it was
+	// registered without one.
+	Message proto2.Message
+}
+
+// ConnState returns the per-connection value stored under key, storing the result of init first if there
+// is none yet. Middleware uses it to keep state that lives as long as the connection.
+func (ctx *Context) ConnState(key interface{}, init func() interface{}) interface{} {
+	return sessionOf(ctx.Conn).value(key, init)
+}
+
+// HandlerFunc handles an inbound message. Returned errors are logged by the router, and reported to the
+// sender in an ERROR frame if they are an *Error.
+type HandlerFunc func(ctx *Context) error
+
+// Middleware wraps a HandlerFunc, e.g. to log, meter or reject messages before they reach it.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+type route struct {
+	// message is an instance of the message type the route decodes, nil for raw routes.
+	message proto2.Message
+	handler HandlerFunc
+	// chain is handler wrapped in the router's middleware.
+	chain HandlerFunc
+}
+
+// Router maps message types to their handlers.
+type Router struct {
+	mu         sync.RWMutex
+	routes     map[byte]*route
+	middleware []Middleware
+}
+
+func newRouter() *Router {
+	return &Router{routes: make(map[byte]*route)}
+}
+
+// Handle registers the handler for a message type, replacing any previous one. Frames of the type are
+// decoded into a new instance of message's type before the handler is called; with a nil message the
+// handler gets the raw Data only.
+func (rt *Router) Handle(messageType byte, message proto2.Message, handler HandlerFunc) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	r := &route{message: message, handler: handler}
+	r.chain = rt.wrap(handler)
+	rt.routes[messageType] = r
+}
+
+// Use appends middleware to the chain wrapping every handler. The first middleware added is the
+// outermost one.
+func (rt *Router) Use(middleware ...Middleware) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.middleware = append(rt.middleware, middleware...)
+	for _, r := range rt.routes {
+		r.chain = rt.wrap(r.handler)
+	}
+}
+
+// wrap applies the middleware to handler. Callers must hold rt.mu.
+func (rt *Router) wrap(handler HandlerFunc) HandlerFunc {
+	chain := handler
+	for i := len(rt.middleware) - 1; i >= 0; i-- {
+		chain = rt.middleware[i](chain)
+	}
+	return chain
+}
+
+// dispatch decodes the message of ctx and runs it through the chain of its route.
+func (rt *Router) dispatch(ctx *Context) {
+	rt.mu.RLock()
+	r, ok := rt.routes[ctx.Type]
+	var prototype proto2.Message
+	var chain HandlerFunc
+	if ok {
+		prototype, chain = r.message, r.chain
+	}
+	rt.mu.RUnlock()
+	if !ok {
+		fmt.Println("Unknown message type", ctx.Type)
+		sendError(ctx.Conn, int(ctx.Type), newError(proto.ErrorCode_UNKNOWN_MESSAGE_TYPE, "unknown message type %d", ctx.Type))
+		return
+	}
+
+	if prototype != nil {
+		message := prototype.ProtoReflect().New().Interface()
+		err := proto2.Unmarshal(ctx.Data, message)
+		if err != nil {
+			fmt.Printf("Error unmarshaling %s: %v\n", messageName(ctx.Type), err)
+			sendError(ctx.Conn, int(ctx.Type), newError(proto.ErrorCode_DECODE_FAILED, "malformed %s: %v", messageName(ctx.Type), err))
+			return
+		}
+		ctx.Message = message
+	}
+
+	err := chain(ctx)
+	if err != nil {
+		fmt.Printf("%s error: %v\n", messageName(ctx.Type), err)
+		var reply *Error
+		if errors.As(err, &reply) {
+			sendError(ctx.Conn, int(ctx.Type), reply)
+		}
+	}
+}
+
+// messageName returns the name of a message type for logs.
+func messageName(messageType byte) string {
+	return proto.MessageType(messageType).String()
+}
@@ -0,0 +1,174 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// dispatchTest runs a frame through rt on the connection and returns the code of the ERROR frame it
+// answered with, if any.
+func dispatchTest(t *testing.T, rt *Router, c *websocket.Conn, underlying *recordingConn, ctx *Context) (proto.ErrorCode, bool) {
+	t.Helper()
+	waitSent(t, c)
+	seen := len(underlying.messages(t))
+	ctx.Conn = c
+	rt.dispatch(ctx)
+	waitSent(t, c)
+	for _, message := range underlying.messages(t)[seen:] {
+		if code, ok := errorCode(message); ok {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+func TestRouter(t *testing.T) {
+	s := New(Config{})
+	c, underlying := openTestConn(s)
+	player := frame(REGISTER, testPlayer("Routed"))[1:]
+	failure := errors.New("failed")
+
+	tests := []struct {
+		name        string
+		messageType byte
+		data        []byte
+		// handled is whether the handler runs, and code the ERROR the sender gets if reply is set.
+		handled bool
+		reply   bool
+		code    proto.ErrorCode
+	}{
+		{"decoded message", REGISTER, player, true, false, 0},
+		{"raw message", REQUEST_PLAYERS, []byte{1, 2, 3}, true, false, 0},
+		{"unknown type", 255, nil, false, true, proto.ErrorCode_UNKNOWN_MESSAGE_TYPE},
+		{"undecodable message", REGISTER, []byte{0xff}, false, true, proto.ErrorCode_DECODE_FAILED},
+		{"reply error", JOIN_ROOM, nil, true, true, proto.ErrorCode_ROOM_FULL},
+		{"plain error", LEAVE_ROOM, nil, true, false, 0},
+	}
+
+	rt := newRouter()
+	var got *Context
+	record := func(err error) HandlerFunc {
+		return func(ctx *Context) error {
+			got = ctx
+			return err
+		}
+	}
+	rt.Handle(REGISTER, &proto.Player{}, record(nil))
+	rt.Handle(REQUEST_PLAYERS, nil, record(nil))
+	rt.Handle(JOIN_ROOM, &proto.Room{}, record(ErrRoomFull))
+	rt.Handle(LEAVE_ROOM, nil, record(failure))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			code, replied := dispatchTest(t, rt, c, underlying, &Context{Type: tt.messageType, Data: tt.data})
+			if (got != nil) != tt.handled {
+				t.Fatalf("handler ran: %v, want %v", got != nil, tt.handled)
+			}
+			if replied != tt.reply || code != tt.code {
+				t.Fatalf("sender got error %v: %v, want %v: %v", code, replied, tt.code, tt.reply)
+			}
+		})
+	}
+
+	// Decoded messages get a fresh instance of the registered type, raw ones only the data.
+	dispatchTest(t, rt, c, underlying, &Context{Type: REGISTER, Data: player})
+	if p, ok := got.Message.(*proto.Player); !ok || p.GetName() != "Routed" {
+		t.Fatalf("handler got message %v, want the player", got.Message)
+	}
+	dispatchTest(t, rt, c, underlying, &Context{Type: REQUEST_PLAYERS, Data: []byte{1, 2, 3}})
+	if got.Message != nil || string(got.Data) != string([]byte{1, 2, 3}) {
+		t.Fatalf("raw handler got message %v and data %v", got.Message, got.Data)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	s := New(Config{})
+	c, underlying := openTestConn(s)
+	other, otherUnderlying := openTestConn(s)
+
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) Middleware {
+			return func(next HandlerFunc) HandlerFunc {
+				return func(ctx *Context) error {
+					calls = append(calls, name+" before")
+					err := next(ctx)
+					calls = append(calls, name+" after")
+					return err
+				}
+			}
+		}
+		rt := newRouter()
+		rt.Use(trace("outer"))
+		rt.Handle(REQUEST_PLAYERS, nil, func(*Context) error {
+			calls = append(calls, "handler")
+			return nil
+		})
+		// Middleware added after a handler still wraps it, inside the earlier middleware.
+		rt.Use(trace("inner"))
+		dispatchTest(t, rt, c, underlying, &Context{Type: REQUEST_PLAYERS})
+		want := []string{"outer before", "inner before", "handler", "inner after", "outer after"}
+		if !slices.Equal(calls, want) {
+			t.Fatalf("calls %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("middleware", func(t *testing.T) {
+		metrics := &Metrics{}
+		handled := 0
+		rt := newRouter()
+		rt.Use(RateLimit(0.001, 3), metrics.Middleware(), RequireRegistration(LEAVE_ROOM), Logger(), Recover())
+		rt.Handle(REQUEST_PLAYERS, nil, func(*Context) error {
+			handled++
+			return nil
+		})
+		rt.Handle(LEAVE_ROOM, nil, func(*Context) error {
+			handled++
+			return nil
+		})
+		rt.Handle(JOIN_ROOM, nil, func(*Context) error {
+			panic("handler failed")
+		})
+
+		tests := []struct {
+			name        string
+			conn        *websocket.Conn
+			underlying  *recordingConn
+			messageType byte
+			playerID    uint32
+			handled     bool
+			reply       bool
+			code        proto.ErrorCode
+		}{
+			{"unguarded type before registering", c, underlying, REQUEST_PLAYERS, 0, true, false, 0},
+			{"guarded type before registering", c, underlying, LEAVE_ROOM, 0, false, true, proto.ErrorCode_NOT_REGISTERED},
+			{"guarded type after registering", c, underlying, LEAVE_ROOM, 1, true, false, 0},
+			{"over the burst", c, underlying, REQUEST_PLAYERS, 1, false, true, proto.ErrorCode_RATE_LIMITED},
+			{"another connection's burst", other, otherUnderlying, REQUEST_PLAYERS, 2, true, false, 0},
+			{"panic", other, otherUnderlying, JOIN_ROOM, 2, false, true, proto.ErrorCode_INTERNAL_ERROR},
+		}
+		for _, tt := range tests {
+			before := handled
+			code, replied := dispatchTest(t, rt, tt.conn, tt.underlying, &Context{Type: tt.messageType, PlayerID: tt.playerID})
+			if (handled > before) != tt.handled {
+				t.Fatalf("%s: handler ran: %v, want %v", tt.name, handled > before, tt.handled)
+			}
+			if replied != tt.reply || code != tt.code {
+				t.Fatalf("%s: sender got error %v: %v, want %v: %v", tt.name, code, replied, tt.code, tt.reply)
+			}
+		}
+
+		// Metrics sits inside the rate limit, so dropped messages are not counted.
+		stats := metrics.Stats()
+		if stats[REQUEST_PLAYERS].Count != 2 || stats[REQUEST_PLAYERS].Errors != 0 ||
+			stats[LEAVE_ROOM].Count != 2 || stats[LEAVE_ROOM].Errors != 1 ||
+			stats[JOIN_ROOM].Count != 1 || stats[JOIN_ROOM].Errors != 1 {
+			t.Fatalf("metrics counted %v", stats)
+		}
+	})
+}
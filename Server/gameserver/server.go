@@ -0,0 +1,412 @@
+// Package gameserver implements the WebSocket game server used by the Godot client.
+package gameserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp"
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// Config holds the settings used by New. Zero values are replaced with the defaults from DefaultConfig.
+type Config struct {
+	Addrs   []string
+	MaxLoad int
+
+	// TickRate is the number of simulation steps per second.
+	TickRate int
+	// SendRate is the number of player snapshots sent to clients per second. Snapshots are only sent
+	// after a simulation step, so a SendRate above TickRate is lowered to TickRate.
+	SendRate int
+	// MaxCatchUpTicks is how many overdue simulation steps a room runs back to back after an overrun
+	// before it gives up on the missed time.
+	MaxCatchUpTicks int
+	// SnapshotWindow is how many sent snapshots are kept per client as possible delta baselines.
+	// Clients that have not acknowledged any of them get a full snapshot.
+	SnapshotWindow int
+	// RelevanceRadius is the horizontal distance within which players are sent to a client.
+	RelevanceRadius float32
+
+	// MoveSpeed, JumpVelocity and Gravity drive the movement of players that send INPUT. They match
+	// the constants of player.gd, so client prediction agrees with the server.
+	MoveSpeed    float32
+	JumpVelocity float32
+	Gravity      float32
+	// InputBuffer is how many inputs are queued per player before the oldest ones are dropped.
+	InputBuffer int
+	// MaxSpeed is the fastest a player may move horizontally between two UPDATE_LOCATION frames,
+	// measured with the server's receive times. MoveTolerance is the most unused movement a player carries
+	// over from one update to the next to absorb jitter, and the slack on the heights they can report.
+	MaxSpeed      float32
+	MoveTolerance float32
+	// MaxMoveViolations is how many rejected or corrected location updates and rejected inputs get a
+	// player kicked.
+	MaxMoveViolations int
+
+	// MinProtocolVersion is the oldest protocol version clients may speak. Above 1 clients have to
+	// start with HELLO; those that do not, or ask for an older version, are rejected.
+	MinProtocolVersion uint32
+	// ServerVersion is reported to clients in Welcome if set.
+	ServerVersion string
+
+	// DefaultRoomName names the room connections are placed in until they join another one.
+	DefaultRoomName   string
+	MaxRooms          int
+	MaxPlayersPerRoom int
+
+	// Spells is the spell table projectiles are spawned from, keyed by Player.current_spell. Players start
+	// with the lowest ID. LoadSpells reads it from a file.
+	Spells map[uint32]Spell
+	// MaxMana is the mana players spawn with and regenerate up to, at ManaRegen per second.
+	MaxMana   float32
+	ManaRegen float32
+	// MinCastInterval is the shortest time between two casts of a player, whatever their cooldowns.
+	MinCastInterval time.Duration
+
+	// SpawnPoints are the places players spawn at, see LoadSpawnPoints. Each spawn goes to the one
+	// farthest from the other living players.
+	SpawnPoints []SpawnPoint
+	// RespawnDelay is how long killed players stay DEAD before they respawn. SpawnProtection is how long
+	// spawned players cannot be damaged, unless they cast.
+	RespawnDelay    time.Duration
+	SpawnProtection time.Duration
+
+	// MinPlayers is how many players a room needs for a match to start. The match begins after a warmup
+	// of WarmupTime and lasts until a player reaches ScoreLimit kills or TimeLimit is over. The results
+	// are shown for PostGameTime before the next round.
+	MinPlayers   int
+	WarmupTime   time.Duration
+	ScoreLimit   int
+	TimeLimit    time.Duration
+	PostGameTime time.Duration
+
+	// GameMode selects free for all or team deathmatch. In team deathmatch players are split into Teams
+	// teams, and FriendlyFire lets teammates damage each other.
+	GameMode     GameMode
+	Teams        int
+	FriendlyFire bool
+
+	// MaxRewind caps how far back in time targets are rewound when testing a shooter's projectile.
+	MaxRewind time.Duration
+	// InterpolationDelay is how far behind the latest snapshot clients render other players.
+	InterpolationDelay time.Duration
+	// PingInterval is how often connections are pinged to measure their round trip time, and how often
+	// connections and players are checked against IdleTimeout and AFKTimeout.
+	PingInterval time.Duration
+	// IdleTimeout is how long any connection, registered or not, may go without sending anything, pongs
+	// included, before it is closed. AFKTimeout is how long a player may go without moving, looking around
+	// or casting before it is disconnected. Both send TIMEOUT_WARNING TimeoutWarning before the timeout.
+	IdleTimeout    time.Duration
+	AFKTimeout     time.Duration
+	TimeoutWarning time.Duration
+
+	// SendQueueSize is how many frames are queued per connection before state snapshots are dropped.
+	SendQueueSize int
+	// SlowClientTimeout is how long a connection's send queue may stay full before it is disconnected.
+	SlowClientTimeout time.Duration
+	// SendBufferSize is how many bytes written to a connection may wait in the network engine for the
+	// client to read them before its send queue stops draining. The engine disconnects clients that
+	// have twice as much waiting.
+	SendBufferSize int
+
+	// IDReuseDelay is how long a released player or projectile ID is held back before it may be handed
+	// out again, so late frames carrying it cannot hit the next entity with that ID. A negative delay
+	// turns the hold back off, letting released IDs be reused at once.
+	IDReuseDelay time.Duration
+	// ResumeGracePeriod is how long the player of a dropped connection is kept in its room, frozen, for
+	// the client to reconnect and resume it.
+	ResumeGracePeriod time.Duration
+}
+
+// DefaultConfig returns the configuration the standalone server runs with.
+func DefaultConfig() Config {
+	return Config{
+		Addrs:           []string{"localhost:8080"},
+		MaxLoad:         1000000,
+		TickRate:        60,
+		SendRate:        60,
+		MaxCatchUpTicks: 5,
+		SnapshotWindow:  32,
+		RelevanceRadius: 30,
+
+		MoveSpeed:    5,
+		JumpVelocity: 4.5,
+		Gravity:      9.8,
+		InputBuffer:  8,
+
+		MaxSpeed:          7.5,
+		MoveTolerance:     1,
+		MaxMoveViolations: 50,
+
+		MinProtocolVersion: legacyProtocol,
+
+		DefaultRoomName:   "Arena",
+		MaxRooms:          64,
+		MaxPlayersPerRoom: 16,
+
+		Spells:    DefaultSpells(),
+		MaxMana:   100,
+		ManaRegen: 10,
+
+		MinCastInterval: 100 * time.Millisecond,
+
+		SpawnPoints:     DefaultSpawnPoints(),
+		RespawnDelay:    3 * time.Second,
+		SpawnProtection: 3 * time.Second,
+
+		MinPlayers:   2,
+		WarmupTime:   10 * time.Second,
+		ScoreLimit:   20,
+		TimeLimit:    10 * time.Minute,
+		PostGameTime: 10 * time.Second,
+
+		GameMode: FreeForAll,
+		Teams:    2,
+
+		MaxRewind:          200 * time.Millisecond,
+		InterpolationDelay: 0,
+		PingInterval:       time.Second,
+		IdleTimeout:        30 * time.Second,
+		AFKTimeout:         5 * time.Minute,
+		TimeoutWarning:     10 * time.Second,
+
+		SendQueueSize:     256,
+		SlowClientTimeout: 5 * time.Second,
+		SendBufferSize:    64 << 10,
+
+		IDReuseDelay:      30 * time.Second,
+		ResumeGracePeriod: 15 * time.Second,
+	}
+}
+
+// GameServer owns the rooms of a game and routes connections between them.
+type GameServer struct {
+	config   Config
+	upgrader *websocket.Upgrader
+	engine   *nbhttp.Engine
+	router   *Router
+	ids      *IDAllocator
+
+	rooms       sync.Map
+	roomsMu     sync.Mutex
+	nextRoomID  uint32
+	defaultRoom *Room
+	running     bool
+
+	// outboxes maps the network engine's connections to the outboxes writing to them, see onWritten.
+	outboxes sync.Map
+
+	// resumes maps the resume tokens handed out to the players they resume.
+	resumes  map[string]*resumable
+	resumeMu sync.Mutex
+}
+
+// New creates a GameServer with the given configuration.
+func New(config Config) *GameServer {
+	defaults := DefaultConfig()
+	if len(config.Addrs) == 0 {
+		config.Addrs = defaults.Addrs
+	}
+	if config.MaxLoad <= 0 {
+		config.MaxLoad = defaults.MaxLoad
+	}
+	if config.TickRate <= 0 {
+		config.TickRate = defaults.TickRate
+	}
+	if config.SendRate <= 0 {
+		config.SendRate = defaults.SendRate
+	}
+	if config.SendRate > config.TickRate {
+		config.SendRate = config.TickRate
+	}
+	if config.MaxCatchUpTicks <= 0 {
+		config.MaxCatchUpTicks = defaults.MaxCatchUpTicks
+	}
+	if config.SnapshotWindow <= 0 {
+		config.SnapshotWindow = defaults.SnapshotWindow
+	}
+	if config.RelevanceRadius <= 0 {
+		config.RelevanceRadius = defaults.RelevanceRadius
+	}
+	if config.MoveSpeed <= 0 {
+		config.MoveSpeed = defaults.MoveSpeed
+	}
+	if config.JumpVelocity <= 0 {
+		config.JumpVelocity = defaults.JumpVelocity
+	}
+	if config.Gravity <= 0 {
+		config.Gravity = defaults.Gravity
+	}
+	if config.InputBuffer <= 0 {
+		config.InputBuffer = defaults.InputBuffer
+	}
+	if config.MaxSpeed <= 0 {
+		config.MaxSpeed = defaults.MaxSpeed
+	}
+	if config.MoveTolerance <= 0 {
+		config.MoveTolerance = defaults.MoveTolerance
+	}
+	if config.MaxMoveViolations <= 0 {
+		config.MaxMoveViolations = defaults.MaxMoveViolations
+	}
+	if config.MinProtocolVersion == 0 {
+		config.MinProtocolVersion = defaults.MinProtocolVersion
+	}
+	if config.DefaultRoomName == "" {
+		config.DefaultRoomName = defaults.DefaultRoomName
+	}
+	if config.MaxRooms <= 0 {
+		config.MaxRooms = defaults.MaxRooms
+	}
+	if config.MaxPlayersPerRoom <= 0 {
+		config.MaxPlayersPerRoom = defaults.MaxPlayersPerRoom
+	}
+	if len(config.Spells) == 0 {
+		config.Spells = defaults.Spells
+	}
+	if config.MaxMana <= 0 {
+		config.MaxMana = defaults.MaxMana
+	}
+	if config.ManaRegen <= 0 {
+		config.ManaRegen = defaults.ManaRegen
+	}
+	if config.MinCastInterval <= 0 {
+		config.MinCastInterval = defaults.MinCastInterval
+	}
+	if len(config.SpawnPoints) == 0 {
+		config.SpawnPoints = defaults.SpawnPoints
+	}
+	if config.RespawnDelay <= 0 {
+		config.RespawnDelay = defaults.RespawnDelay
+	}
+	if config.SpawnProtection <= 0 {
+		config.SpawnProtection = defaults.SpawnProtection
+	}
+	if config.MinPlayers <= 0 {
+		config.MinPlayers = defaults.MinPlayers
+	}
+	if config.WarmupTime <= 0 {
+		config.WarmupTime = defaults.WarmupTime
+	}
+	if config.ScoreLimit <= 0 {
+		config.ScoreLimit = defaults.ScoreLimit
+	}
+	if config.TimeLimit <= 0 {
+		config.TimeLimit = defaults.TimeLimit
+	}
+	if config.PostGameTime <= 0 {
+		config.PostGameTime = defaults.PostGameTime
+	}
+	if config.Teams <= 0 {
+		config.Teams = defaults.Teams
+	}
+	if config.MaxRewind <= 0 {
+		config.MaxRewind = defaults.MaxRewind
+	}
+	if config.PingInterval <= 0 {
+		config.PingInterval = defaults.PingInterval
+	}
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = defaults.IdleTimeout
+	}
+	if config.AFKTimeout <= 0 {
+		config.AFKTimeout = defaults.AFKTimeout
+	}
+	if config.TimeoutWarning <= 0 {
+		config.TimeoutWarning = defaults.TimeoutWarning
+	}
+	if config.SendQueueSize <= 0 {
+		config.SendQueueSize = defaults.SendQueueSize
+	}
+	if config.SlowClientTimeout <= 0 {
+		config.SlowClientTimeout = defaults.SlowClientTimeout
+	}
+	if config.SendBufferSize <= 0 {
+		config.SendBufferSize = defaults.SendBufferSize
+	}
+	if config.IDReuseDelay == 0 {
+		config.IDReuseDelay = defaults.IDReuseDelay
+	}
+	if config.ResumeGracePeriod <= 0 {
+		config.ResumeGracePeriod = defaults.ResumeGracePeriod
+	}
+
+	s := &GameServer{config: config, router: newRouter(), ids: NewIDAllocator(config.IDReuseDelay), resumes: make(map[string]*resumable)}
+	s.registerHandlers()
+	s.Use(Recover(), RequireRegistration(UPDATE_LOCATION, INPUT, INIT_CAST, SELECT_SPELL, SWITCH_TEAM))
+	s.defaultRoom = s.newRoom(config.DefaultRoomName, config.MaxPlayersPerRoom)
+	s.upgrader = websocket.NewUpgrader()
+	s.upgrader.OnOpen(s.OnOpen)
+	s.upgrader.OnMessage(s.OnMessage)
+	s.upgrader.OnClose(s.OnClose)
+	s.upgrader.SetPongHandler(s.onPong)
+	return s
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection, so the server can be mounted on any mux.
+func (s *GameServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		fmt.Printf("Error upgrading %s: %v\n", r.RemoteAddr, err)
+		return
+	}
+	fmt.Println("Upgraded:", conn.RemoteAddr().String())
+}
+
+// Start starts listening on the configured addresses and starts the tick loop of every room.
+func (s *GameServer) Start() error {
+	mux := &http.ServeMux{}
+	mux.Handle("/", s)
+	engine := nbhttp.NewEngine(nbhttp.Config{
+		Network:                 "tcp",
+		Addrs:                   s.config.Addrs,
+		MaxLoad:                 s.config.MaxLoad,
+		ReleaseWebsocketPayload: true,
+		Handler:                 mux,
+		// Outboxes keep the buffered bytes under SendBufferSize, see outbox. This only catches what
+		// slips past them.
+		MaxWriteBufferSize: 2 * s.config.SendBufferSize,
+	})
+	engine.OnWrittenSize(s.onWritten)
+
+	err := engine.Start()
+	if err != nil {
+		return err
+	}
+	s.engine = engine
+
+	s.roomsMu.Lock()
+	s.running = true
+	s.rooms.Range(func(_, value interface{}) bool {
+		value.(*Room).start()
+		return true
+	})
+	s.roomsMu.Unlock()
+	return nil
+}
+
+// Shutdown stops the tick loop of every room and gracefully shuts down the network engine.
+func (s *GameServer) Shutdown(ctx context.Context) error {
+	if s.engine == nil {
+		return nil
+	}
+
+	s.roomsMu.Lock()
+	s.running = false
+	s.roomsMu.Unlock()
+
+	s.rooms.Range(func(_, value interface{}) bool {
+		room := value.(*Room)
+		room.shutdown()
+		select {
+		case <-room.done:
+		case <-ctx.Done():
+		}
+		return true
+	})
+	return s.engine.Shutdown(ctx)
+}
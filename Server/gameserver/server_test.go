@@ -0,0 +1,72 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// TestServersAreIndependent runs two GameServers in one process and checks that neither sees the
+// other's players, rooms or handlers.
+func TestServersAreIndependent(t *testing.T) {
+	servers := []*GameServer{New(Config{}), New(Config{})}
+	names := []string{"First", "Second"}
+	conns := make([]*websocket.Conn, len(servers))
+	underlyings := make([]*recordingConn, len(servers))
+	ids := make([]uint32, len(servers))
+	handled := make([]int, len(servers))
+	for i, s := range servers {
+		conns[i], underlyings[i], ids[i] = registerPlayer(t, s, names[i])
+		s.Handle(255, nil, func(*Context) error {
+			handled[i]++
+			return nil
+		})
+	}
+	servers[0].newRoom("Only on the first", 0)
+
+	for i, s := range servers {
+		c, underlying := conns[i], underlyings[i]
+		waitSent(t, c)
+		seen := len(underlying.messages(t))
+		s.OnMessage(c, websocket.BinaryMessage, []byte{REQUEST_PLAYERS})
+		s.OnMessage(c, websocket.BinaryMessage, []byte{255})
+		waitSent(t, c)
+
+		var got []uint32
+		for _, message := range underlying.messages(t)[seen:] {
+			if message[0] != REQUEST_PLAYERS {
+				continue
+			}
+			players := &proto.Players{}
+			if err := proto2.Unmarshal(message[1:], players); err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range players.GetPlayer() {
+				got = append(got, p.GetId())
+			}
+		}
+		if len(got) != 1 || got[0] != ids[i] {
+			t.Fatalf("%s server lists players %v, want only %d", names[i], got, ids[i])
+		}
+		if _, ok := s.Room(s.DefaultRoom().id + 1); ok != (i == 0) {
+			t.Fatalf("%s server has the first server's second room: %v", names[i], ok)
+		}
+		// Each server has run its own handler once by now.
+		if handled[0] != 1 || handled[1] != i {
+			t.Fatalf("%s server ran handlers %v times, want only its own", names[i], handled)
+		}
+	}
+}
+
+func TestServeHTTPWithoutUpgrade(t *testing.T) {
+	s := New(Config{})
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("plain request got status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,263 @@
+package gameserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// session is the per-connection state stored with websocket.Conn.SetSession.
+// playerID is zero until the connection has registered.
+type session struct {
+	mu       sync.Mutex
+	playerID uint32
+	room     *Room
+
+	// roundTrip is the smoothed round trip time measured with WebSocket pings, jitter the smoothed
+	// difference between consecutive samples and lastSample the newest sample.
+	roundTrip  time.Duration
+	jitter     time.Duration
+	lastSample time.Duration
+	// lastReceived is when the last frame or pong arrived, idleWarned whether the connection was warned
+	// about being idle since.
+	lastReceived time.Time
+	idleWarned   bool
+	// kicked is set for connections closed for misbehaving, whose player must not be resumed.
+	kicked bool
+	// delta is nil until the client acknowledges its first snapshot.
+	delta *deltaState
+	// relevant holds the IDs of the players the connection currently receives snapshots of, ascending.
+	relevant []uint32
+
+	// protocol is the version negotiated with HELLO, zero until the handshake.
+	protocol uint32
+	// receiveSequence is the sequence of the last envelope received and sequenced whether one was, see
+	// readFrame.
+	receiveSequence uint32
+	sequenced       bool
+	// offenses counts the misbehavior of the connection's player.
+	offenses offenses
+	// resumeToken is the token that resumes the connection's player, empty until it registers. It is
+	// only changed with GameServer.resumeMu held.
+	resumeToken string
+	// out queues the frames sent to the connection.
+	out *outbox
+
+	// values holds the per-connection state of middleware, see Context.ConnState.
+	values map[interface{}]interface{}
+}
+
+// offenses counts the invalid location updates and inputs and the rejected casts of a player. They are
+// kept with the player's connection rather than in their room, so leaving the room or reconnecting does
+// not clear them.
+type offenses struct {
+	moveViolations int
+	rejectedCasts  int
+}
+
+func sessionOf(c *websocket.Conn) *session {
+	sess, _ := c.Session().(*session)
+	return sess
+}
+
+func (s *session) get() (uint32, *Room) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.playerID, s.room
+}
+
+// set binds the session to a player and room, keeping the member count of both rooms up to date.
+func (s *session) set(playerID uint32, room *Room) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.room != room {
+		if s.room != nil {
+			s.room.members.Add(-1)
+			s.room.sessions.Delete(s)
+		}
+		if room != nil {
+			room.members.Add(1)
+			room.sessions.Store(s, struct{}{})
+		}
+		if s.delta != nil {
+			s.delta.reset()
+		}
+		s.relevant = nil
+	}
+	s.playerID = playerID
+	s.room = room
+}
+
+func (s *session) setResumeToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resumeToken = token
+}
+
+// takeResumeToken returns the session's resume token and clears it.
+func (s *session) takeResumeToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := s.resumeToken
+	s.resumeToken = ""
+	return token
+}
+
+func (s *session) rtt() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.roundTrip
+}
+
+func (s *session) latency() (time.Duration, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.roundTrip, s.jitter
+}
+
+// recordRTT folds a round trip sample into the smoothed RTT, weighting new samples by 1/8 like TCP does,
+// and the difference to the previous sample into the jitter, weighting it by 1/16 like RTP does.
+func (s *session) recordRTT(sample time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.roundTrip == 0 {
+		s.roundTrip = sample
+		s.lastSample = sample
+		return
+	}
+	s.roundTrip += (sample - s.roundTrip) / 8
+
+	difference := sample - s.lastSample
+	if difference < 0 {
+		difference = -difference
+	}
+	s.jitter += (difference - s.jitter) / 16
+	s.lastSample = sample
+}
+
+// received records that a frame or pong arrived from the connection.
+func (s *session) received(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastReceived = now
+	s.idleWarned = false
+}
+
+func (s *session) kick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.kicked = true
+}
+
+func (s *session) wasKicked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.kicked
+}
+
+// snapshotBaseline records world as sent to the connection and returns the baseline to encode it
+// against, see deltaState.baseline. ok is false if the connection does not acknowledge snapshots.
+func (s *session) snapshotBaseline(world worldState, tick uint64, whole bool) (base *sentSnapshot, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.delta == nil {
+		return nil, false
+	}
+	return s.delta.baseline(world, tick, whole), true
+}
+
+// ackSnapshot records a SNAPSHOT_ACK, switching the connection to delta snapshots on the first one.
+func (s *session) ackSnapshot(tick uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.delta == nil {
+		if s.room == nil {
+			return
+		}
+		s.delta = newDeltaState(s.room.config.SnapshotWindow)
+	}
+	s.delta.ack(tick)
+}
+
+func (s *session) handshaken() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.protocol != 0
+}
+
+// protocolVersion returns the protocol version the connection speaks.
+func (s *session) protocolVersion() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.protocol == 0 {
+		return legacyProtocol
+	}
+	return s.protocol
+}
+
+func (s *session) setProtocol(version uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.protocol = version
+}
+
+// receivedSequence records the sequence of a received envelope and returns the previous one and how many
+// sequences were skipped in between. It reports false, recording nothing, if the sequence is not above the
+// previous one. The first envelope is accepted whatever its sequence, so clients may count from 0 or 1.
+func (s *session) receivedSequence(sequence uint32) (uint32, uint32, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, first := s.receiveSequence, !s.sequenced
+	if !first && sequence <= last {
+		return last, 0, false
+	}
+	s.receiveSequence = sequence
+	s.sequenced = true
+	if first {
+		return last, 0, true
+	}
+	return last, sequence - last - 1, true
+}
+
+func (s *session) value(key interface{}, init func() interface{}) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.values[key]; ok {
+		return v
+	}
+	if s.values == nil {
+		s.values = make(map[interface{}]interface{})
+	}
+	v := init()
+	s.values[key] = v
+	return v
+}
+
+// recordMoveViolation counts a movement violation of the connection's player and returns the new count.
+func (s *session) recordMoveViolation() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offenses.moveViolations++
+	return s.offenses.moveViolations
+}
+
+// recordRejectedCast counts a rejected cast of the connection's player and returns the new count.
+func (s *session) recordRejectedCast() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offenses.rejectedCasts++
+	return s.offenses.rejectedCasts
+}
+
+func (s *session) offenseCounts() offenses {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offenses
+}
+
+func (s *session) setOffenses(o offenses) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offenses = o
+}
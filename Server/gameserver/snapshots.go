@@ -0,0 +1,385 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// playerState is the part of a player that is replicated in snapshots.
+type playerState struct {
+	name    string
+	color   string
+	rotY    float32
+	rotX    float32
+	health  float32
+	spell   uint32
+	casting bool
+	hasPos  bool
+	pos     vec3
+	state   proto.PLAYER_STATE
+	// lastInput is the sequence of the last input applied to the player, 0 if they never sent one.
+	lastInput uint32
+	mana      float32
+	// readyTick is Player.ready_tick, respawnTick Player.respawn_tick and protectedTick
+	// Player.protected_tick, 0 if unset.
+	readyTick     uint64
+	respawnTick   uint64
+	protectedTick uint64
+	// team is Player.team, 0 if unset.
+	team uint32
+}
+
+func capturePlayer(p *proto.Player) playerState {
+	ps := playerState{
+		name:    p.GetName(),
+		color:   p.GetPlayerColor(),
+		rotY:    p.GetRotationY(),
+		rotX:    p.GetRotationX(),
+		health:  p.GetHealth(),
+		spell:   p.GetCurrentSpell(),
+		casting: p.GetCasting(),
+		state:   p.GetPlayerState(),
+
+		lastInput: p.GetLastInput(),
+		mana:      p.GetMana(),
+		readyTick: p.GetReadyTick(),
+
+		respawnTick:   p.GetRespawnTick(),
+		protectedTick: p.GetProtectedTick(),
+		team:          p.GetTeam(),
+	}
+	if len(p.GetPos()) > 0 {
+		ps.hasPos = true
+		ps.pos = positionVec(p.GetPos()[0])
+	}
+	return ps
+}
+
+// worldState is every player's replicated state at one tick. It is shared between clients and never
+// modified once captured.
+type worldState map[uint32]playerState
+
+// captureWorld copies the replicated state of every player together with the current tick.
+func (r *Room) captureWorld() (worldState, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	world := make(worldState)
+	r.players.Range(func(key, value interface{}) bool {
+		world[key.(uint32)] = capturePlayer(value.(*proto.Player))
+		return true
+	})
+	return world, r.Tick()
+}
+
+// delta returns the PlayerDelta carrying the fields of cur that differ from base. A nil base yields
+// every field. It returns nil if nothing changed.
+func delta(id uint32, base *playerState, cur playerState) *proto.PlayerDelta {
+	d := &proto.PlayerDelta{Id: proto2.Uint32(id)}
+	changed := false
+	if base == nil || base.name != cur.name {
+		d.Name = proto2.String(cur.name)
+		changed = true
+	}
+	if base == nil || base.color != cur.color {
+		d.PlayerColor = proto2.String(cur.color)
+		changed = true
+	}
+	if base == nil || base.rotY != cur.rotY {
+		d.RotationY = proto2.Float32(cur.rotY)
+		changed = true
+	}
+	if base == nil || base.rotX != cur.rotX {
+		d.RotationX = proto2.Float32(cur.rotX)
+		changed = true
+	}
+	if base == nil || base.health != cur.health {
+		d.Health = proto2.Float32(cur.health)
+		changed = true
+	}
+	if base == nil || base.spell != cur.spell {
+		d.CurrentSpell = proto2.Uint32(cur.spell)
+		changed = true
+	}
+	if base == nil || base.casting != cur.casting {
+		d.Casting = proto2.Bool(cur.casting)
+		changed = true
+	}
+	if cur.hasPos && (base == nil || !base.hasPos || base.pos != cur.pos) {
+		d.Pos = []*proto.Player_Position{cur.pos.position()}
+		changed = true
+	}
+	if base == nil || base.state != cur.state {
+		state := cur.state
+		d.PlayerState = &state
+		changed = true
+	}
+	if (base == nil && cur.lastInput != 0) || (base != nil && base.lastInput != cur.lastInput) {
+		d.LastInput = proto2.Uint32(cur.lastInput)
+		changed = true
+	}
+	if base == nil || base.mana != cur.mana {
+		d.Mana = proto2.Float32(cur.mana)
+		changed = true
+	}
+	if (base == nil && cur.readyTick != 0) || (base != nil && base.readyTick != cur.readyTick) {
+		d.ReadyTick = proto2.Uint64(cur.readyTick)
+		changed = true
+	}
+	if (base == nil && cur.respawnTick != 0) || (base != nil && base.respawnTick != cur.respawnTick) {
+		d.RespawnTick = proto2.Uint64(cur.respawnTick)
+		changed = true
+	}
+	if (base == nil && cur.protectedTick != 0) || (base != nil && base.protectedTick != cur.protectedTick) {
+		d.ProtectedTick = proto2.Uint64(cur.protectedTick)
+		changed = true
+	}
+	if (base == nil && cur.team != 0) || (base != nil && base.team != cur.team) {
+		d.Team = proto2.Uint32(cur.team)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return d
+}
+
+// buildSnapshot encodes world as a delta against base, or as a full snapshot if base is nil.
+func buildSnapshot(world worldState, tick uint64, base worldState, baseTick uint64) *proto.Snapshot {
+	snapshot := &proto.Snapshot{Tick: proto2.Uint64(tick)}
+	if base != nil {
+		snapshot.BaselineTick = proto2.Uint64(baseTick)
+	}
+
+	for id, cur := range world {
+		var previous *playerState
+		if base != nil {
+			if ps, ok := base[id]; ok {
+				previous = &ps
+			}
+		}
+		if d := delta(id, previous, cur); d != nil {
+			snapshot.Player = append(snapshot.Player, d)
+		}
+	}
+	for id := range base {
+		if _, ok := world[id]; !ok {
+			snapshot.Removed = append(snapshot.Removed, id)
+		}
+	}
+	return snapshot
+}
+
+// sentSnapshot is a snapshot sent to a client, kept until it is acknowledged or falls out of the window.
+type sentSnapshot struct {
+	tick  uint64
+	world worldState
+	// whole is set if world was every player in the room at tick, not just the relevant ones.
+	whole bool
+}
+
+// deltaState tracks the snapshots sent to one client and the newest one it acknowledged.
+type deltaState struct {
+	sent  []sentSnapshot
+	next  int
+	acked *sentSnapshot
+}
+
+func newDeltaState(window int) *deltaState {
+	return &deltaState{sent: make([]sentSnapshot, window)}
+}
+
+// reset drops every baseline, so the next snapshot is a full one.
+func (d *deltaState) reset() {
+	for i := range d.sent {
+		d.sent[i] = sentSnapshot{}
+	}
+	d.acked = nil
+}
+
+func (d *deltaState) inWindow(tick uint64) bool {
+	for i := range d.sent {
+		if d.sent[i].world != nil && d.sent[i].tick == tick {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *deltaState) ack(tick uint64) {
+	if d.acked != nil && tick <= d.acked.tick {
+		return
+	}
+	for i := range d.sent {
+		if d.sent[i].world != nil && d.sent[i].tick == tick {
+			acked := d.sent[i]
+			d.acked = &acked
+			return
+		}
+	}
+}
+
+// baseline records world as sent and returns the snapshot to encode it against: the last acknowledged
+// one if that is still inside the window, and nil for a full snapshot otherwise.
+func (d *deltaState) baseline(world worldState, tick uint64, whole bool) *sentSnapshot {
+	var base *sentSnapshot
+	if d.acked != nil && d.inWindow(d.acked.tick) {
+		base = d.acked
+	} else {
+		d.acked = nil
+	}
+
+	d.sent[d.next] = sentSnapshot{tick: tick, world: world, whole: whole}
+	d.next = (d.next + 1) % len(d.sent)
+	return base
+}
+
+// snapshotFrames builds the frames of one snapshot tick. Frames that come out the same for several
+// connections, the Players list and the Snapshots of connections that see the whole world, are built
+// once and shared.
+type snapshotFrames struct {
+	world worldState
+	tick  uint64
+	// ids are the IDs of the players in world, see worldState.ids.
+	ids []uint32
+
+	// entries holds every player marshaled for Players messages, built on first use.
+	entries *playerEntries
+	players *sharedFrame
+	// full is the full Snapshot of the world, deltas holds the deltas against whole baselines keyed by
+	// their tick.
+	full   *sharedFrame
+	deltas map[uint64]*sharedFrame
+}
+
+func newSnapshotFrames(world worldState, tick uint64) *snapshotFrames {
+	return &snapshotFrames{world: world, tick: tick, ids: world.ids(), deltas: make(map[uint64]*sharedFrame)}
+}
+
+// playerList returns a frame of the given type with the Players message of the listed players. The
+// caller owns a reference to the frame.
+func (f *snapshotFrames) playerList(messageType byte, ids []uint32) (*sharedFrame, error) {
+	if f.entries == nil {
+		entries, err := newPlayerEntries(f.world, f.tick)
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+	}
+	return f.entries.frame(messageType, ids), nil
+}
+
+// visibleIDs returns the IDs of the players in visible, sharing the ones of the world when it is all of it.
+func (f *snapshotFrames) visibleIDs(visible worldState) []uint32 {
+	if len(visible) == len(f.world) {
+		return f.ids
+	}
+	return visible.ids()
+}
+
+// playersFrame returns the UPDATE_LOCATION frame with the Players list of the visible players. The caller
+// owns a reference to the frame.
+func (f *snapshotFrames) playersFrame(ids []uint32) (*sharedFrame, error) {
+	if len(ids) != len(f.world) {
+		return f.playerList(UPDATE_LOCATION, ids)
+	}
+	if f.players == nil {
+		frame, err := f.playerList(UPDATE_LOCATION, f.ids)
+		if err != nil {
+			return nil, err
+		}
+		f.players = frame
+	}
+	f.players.retain()
+	return f.players, nil
+}
+
+// snapshotFrame returns the UPDATE_LOCATION frame with the Snapshot of visible against base, or the full
+// Snapshot if base is nil. The caller owns a reference to the frame.
+func (f *snapshotFrames) snapshotFrame(visible worldState, base *sentSnapshot) (*sharedFrame, error) {
+	var baseWorld worldState
+	var baseTick uint64
+	if base != nil {
+		baseWorld, baseTick = base.world, base.tick
+	}
+	if len(visible) != len(f.world) || (base != nil && !base.whole) {
+		return marshalFrame(UPDATE_LOCATION, buildSnapshot(visible, f.tick, baseWorld, baseTick))
+	}
+
+	cached := f.full
+	if base != nil {
+		cached = f.deltas[baseTick]
+	}
+	if cached == nil {
+		frame, err := marshalFrame(UPDATE_LOCATION, buildSnapshot(f.world, f.tick, baseWorld, baseTick))
+		if err != nil {
+			return nil, err
+		}
+		cached = frame
+		if base != nil {
+			f.deltas[baseTick] = cached
+		} else {
+			f.full = cached
+		}
+	}
+	cached.retain()
+	return cached, nil
+}
+
+// release drops the references to the shared frames.
+func (f *snapshotFrames) release() {
+	for _, frame := range []*sharedFrame{f.players, f.full} {
+		if frame != nil {
+			frame.release()
+		}
+	}
+	for _, frame := range f.deltas {
+		frame.release()
+	}
+}
+
+// sendSnapshots sends the tick's player snapshot to every connection in the room, limited to the players
+// relevant to it. Clients that acknowledge snapshots get a Snapshot relative to their last acknowledged
+// one; the rest get the Players list.
+func (r *Room) sendSnapshots() {
+	world, tick := r.captureWorld()
+	grid := newSpatialGrid(world, r.config.RelevanceRadius)
+	frames := newSnapshotFrames(world, tick)
+	defer frames.release()
+
+	r.conns.Range(func(key, value interface{}) bool {
+		conn := value.(*websocket.Conn)
+		sess := sessionOf(conn)
+		visible := r.relevantWorld(world, grid, key.(uint32))
+		ids := frames.visibleIDs(visible)
+
+		entered, left := sess.updateRelevance(ids)
+		sendRelevanceChanges(conn, frames, entered, left)
+
+		var frame *sharedFrame
+		var err error
+		if base, ok := sess.snapshotBaseline(visible, tick, len(visible) == len(world)); ok {
+			frame, err = frames.snapshotFrame(visible, base)
+			if err != nil {
+				fmt.Printf("Error marshaling Snapshot: %v\n", err)
+				return true
+			}
+		} else {
+			frame, err = frames.playersFrame(ids)
+			if err != nil {
+				fmt.Printf("Error marshaling Players: %v\n", err)
+				return true
+			}
+		}
+
+		err = sendFrame(conn, frame)
+		frame.release()
+		if err != nil {
+			fmt.Println("Failed to send message to client:", err)
+		}
+		return true
+	})
+}
@@ -0,0 +1,199 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"testing"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// discardConn is a recordingConn that throws away what is written to it.
+type discardConn struct {
+	recordingConn
+}
+
+func (c *discardConn) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// benchmarkRoom fills a room with players, every other one acknowledging snapshots and every third one
+// speaking protocol version 1.
+func benchmarkRoom(b *testing.B, players int) (*Room, []*websocket.Conn) {
+	s := New(Config{MaxPlayersPerRoom: players})
+	r := s.defaultRoom
+	conns := make([]*websocket.Conn, 0, players)
+	for i := 0; i < players; i++ {
+		c := websocket.NewServerConn(s.upgrader, &discardConn{}, "", false, false)
+		s.OnOpen(c)
+		if i%3 != 0 {
+			sessionOf(c).setProtocol(envelopeProtocol)
+		}
+		_, err := r.RegisterPlayer(&proto.Player{Name: proto2.String(fmt.Sprintf("Player %d", i))}, c)
+		if err != nil {
+			b.Fatal(err)
+		}
+		conns = append(conns, c)
+	}
+	return r, conns
+}
+
+// TestSharedFrameEncoding checks that the hand-assembled Players and Envelope encodings decode to the
+// messages they stand for.
+func TestSharedFrameEncoding(t *testing.T) {
+	world := worldState{
+		1: {name: "One", hasPos: true, pos: vec3{1, 1, 1}, lastInput: 3},
+		2: {name: "Two"},
+		3: {name: "Three", health: 50},
+	}
+	entries, err := newPlayerEntries(world, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := entries.frame(RELEVANCE_ENTER, []uint32{3, 1, 4})
+	defer f.release()
+	if f.data[0] != RELEVANCE_ENTER {
+		t.Fatalf("frame type is %d, want %d", f.data[0], RELEVANCE_ENTER)
+	}
+	want := &proto.Players{Player: []*proto.Player{world[3].player(3), world[1].player(1)}, Tick: proto2.Uint64(42)}
+	got := &proto.Players{}
+	if err := proto2.Unmarshal(f.data[1:], got); err != nil {
+		t.Fatal(err)
+	}
+	if !proto2.Equal(got, want) {
+		t.Fatalf("Players decoded to %v, want %v", got, want)
+	}
+
+	envelope, err := proto2.Marshal(&proto.Envelope{
+		Type:     proto.MessageType_RELEVANCE_ENTER.Enum(),
+		Sequence: proto2.Uint32(7),
+		Payload:  f.data[1:],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := appendEnvelope(nil, RELEVANCE_ENTER, 7, f.data[1:]); string(got) != string(envelope) {
+		t.Fatalf("appendEnvelope wrote %v, want %v", got, envelope)
+	}
+}
+
+func TestDeltaSnapshots(t *testing.T) {
+	s := New(Config{SnapshotWindow: 3})
+	room := s.defaultRoom
+	c, underlying, playerID := registerPlayer(t, s, "Viewer")
+	_, _, otherID := registerPlayer(t, s, "Other")
+	sess := sessionOf(c)
+
+	// send runs a snapshot tick after changing the viewer and returns the Snapshot sent to it.
+	send := func(change func(p *proto.Player)) *proto.Snapshot {
+		t.Helper()
+		room.mu.Lock()
+		room.tick.Add(1)
+		value, _ := room.players.Load(playerID)
+		if change != nil {
+			change(value.(*proto.Player))
+		}
+		room.mu.Unlock()
+
+		room.sendSnapshots()
+		waitSent(t, c)
+		messages := underlying.messages(t)
+		last := messages[len(messages)-1]
+		snapshot := &proto.Snapshot{}
+		if last[0] != UPDATE_LOCATION || proto2.Unmarshal(last[1:], snapshot) != nil || snapshot.GetTick() != room.Tick() {
+			t.Fatalf("tick %d sent %v, want a Snapshot", room.Tick(), last)
+		}
+		return snapshot
+	}
+	deltaOf := func(snapshot *proto.Snapshot, id uint32) *proto.PlayerDelta {
+		for _, d := range snapshot.GetPlayer() {
+			if d.GetId() == id {
+				return d
+			}
+		}
+		return nil
+	}
+
+	// The first acknowledgement switches the client to snapshots, but it acknowledges a Players list,
+	// so there is no baseline yet.
+	sess.ackSnapshot(room.Tick())
+	full := send(nil)
+	if full.BaselineTick != nil || len(full.GetPlayer()) != 2 || deltaOf(full, otherID).GetName() != "Other" {
+		t.Fatalf("first snapshot %v, want a full one", full)
+	}
+
+	// Against an acknowledged baseline only what changed is sent.
+	sess.ackSnapshot(full.GetTick())
+	moved := send(func(p *proto.Player) {
+		p.Pos[0].X = proto2.Float32(p.GetPos()[0].GetX() + 1)
+		p.ReadyTick = proto2.Uint64(100)
+	})
+	d := deltaOf(moved, playerID)
+	if moved.GetBaselineTick() != full.GetTick() || len(moved.GetPlayer()) != 1 || len(d.GetPos()) != 1 ||
+		d.GetReadyTick() != 100 || d.Name != nil || d.Health != nil {
+		t.Fatalf("delta snapshot %v, want the viewer's position and ready tick against tick %d", moved, full.GetTick())
+	}
+
+	// Acknowledgements of older snapshots and of ticks never sent are ignored.
+	sess.ackSnapshot(moved.GetTick())
+	sess.ackSnapshot(full.GetTick())
+	sess.ackSnapshot(room.Tick() + 10)
+	cleared := send(func(p *proto.Player) { p.ReadyTick = nil })
+	d = deltaOf(cleared, playerID)
+	if cleared.GetBaselineTick() != moved.GetTick() || len(cleared.GetPlayer()) != 1 || d.ReadyTick == nil ||
+		d.GetReadyTick() != 0 || len(d.GetPos()) != 0 {
+		t.Fatalf("delta snapshot %v, want the ready tick cleared to 0 against tick %d", cleared, moved.GetTick())
+	}
+
+	// Players that left since the baseline are listed as removed.
+	sess.ackSnapshot(cleared.GetTick())
+	room.removePlayer(otherID)
+	left := send(nil)
+	if left.GetBaselineTick() != cleared.GetTick() || len(left.GetPlayer()) != 0 ||
+		len(left.GetRemoved()) != 1 || left.GetRemoved()[0] != otherID {
+		t.Fatalf("delta snapshot %v, want player %d removed", left, otherID)
+	}
+
+	// Once the acknowledged snapshot falls out of the window of 3, the client gets a full snapshot again.
+	sess.ackSnapshot(left.GetTick())
+	for i := 0; i < 3; i++ {
+		if snapshot := send(nil); snapshot.GetBaselineTick() != left.GetTick() {
+			t.Fatalf("snapshot %v, want a delta against tick %d", snapshot, left.GetTick())
+		}
+	}
+	if snapshot := send(nil); snapshot.BaselineTick != nil || len(snapshot.GetPlayer()) != 1 {
+		t.Fatalf("snapshot %v, want a full one after the baseline left the window", snapshot)
+	}
+}
+
+// BenchmarkSendSnapshots measures one snapshot tick: every player moves, the room sends its snapshots
+// and every connection writes them.
+func BenchmarkSendSnapshots(b *testing.B) {
+	for _, players := range []int{100, 500, 1000} {
+		b.Run(fmt.Sprintf("players=%d", players), func(b *testing.B) {
+			r, conns := benchmarkRoom(b, players)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.mu.Lock()
+				r.tick.Add(1)
+				r.players.Range(func(_, value interface{}) bool {
+					pos := value.(*proto.Player).GetPos()[0]
+					pos.X = proto2.Float32(pos.GetX() + 0.01)
+					return true
+				})
+				r.mu.Unlock()
+
+				r.sendSnapshots()
+				for j, c := range conns {
+					waitSent(b, c)
+					if j%2 == 0 {
+						sessionOf(c).ackSnapshot(r.Tick())
+					}
+				}
+			}
+		})
+	}
+}
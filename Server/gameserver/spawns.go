@@ -0,0 +1,146 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// SpawnPoint is a named place in the arena where players spawn.
+type SpawnPoint struct {
+	Name string  `json:"name"`
+	X    float32 `json:"x"`
+	Y    float32 `json:"y"`
+	Z    float32 `json:"z"`
+}
+
+// DefaultSpawnPoints returns spawn points along the edges of the arena of the Godot client.
+func DefaultSpawnPoints() []SpawnPoint {
+	return []SpawnPoint{
+		{Name: "North", X: 0, Y: floorY, Z: -7},
+		{Name: "NorthEast", X: 7, Y: floorY, Z: -7},
+		{Name: "East", X: 7, Y: floorY, Z: 0},
+		{Name: "SouthEast", X: 7, Y: floorY, Z: 7},
+		{Name: "South", X: 0, Y: floorY, Z: 7},
+		{Name: "SouthWest", X: -7, Y: floorY, Z: 7},
+		{Name: "West", X: -7, Y: floorY, Z: 0},
+		{Name: "NorthWest", X: -7, Y: floorY, Z: -7},
+	}
+}
+
+// LoadSpawnPoints reads the spawn points for Config.SpawnPoints from a JSON map data file holding a
+// "spawn_points" list. Every spawn point needs a unique name and a position inside the arena.
+func LoadSpawnPoints(path string) ([]SpawnPoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		SpawnPoints []SpawnPoint `json:"spawn_points"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(file.SpawnPoints) == 0 {
+		return nil, fmt.Errorf("%s: no spawn points", path)
+	}
+
+	names := make(map[string]bool, len(file.SpawnPoints))
+	for _, point := range file.SpawnPoints {
+		switch {
+		case point.Name == "":
+			return nil, fmt.Errorf("%s: spawn point without a name", path)
+		case names[point.Name]:
+			return nil, fmt.Errorf("%s: spawn point %q is defined twice", path, point.Name)
+		case !finite(point.X, point.Y, point.Z):
+			return nil, fmt.Errorf("%s: spawn point %q is not a valid position", path, point.Name)
+		case math.Abs(float64(point.X)) > arenaHalfSize || math.Abs(float64(point.Z)) > arenaHalfSize:
+			return nil, fmt.Errorf("%s: spawn point %q is outside the arena", path, point.Name)
+		}
+		names[point.Name] = true
+	}
+	return file.SpawnPoints, nil
+}
+
+// spawnPoint picks the spawn point farthest from the nearest living enemy of the player, or a random one
+// if no enemy is alive. Ties are broken at random. Callers must hold r.mu.
+func (r *Room) spawnPoint(spawning *proto.Player) SpawnPoint {
+	var best []SpawnPoint
+	bestDistance := float32(-1)
+	for _, point := range r.config.SpawnPoints {
+		at := vec3{point.X, point.Y, point.Z}
+		distance := float32(math.Inf(1))
+		r.players.Range(func(_, value interface{}) bool {
+			p := value.(*proto.Player)
+			if !r.enemies(spawning, p) || p.GetPlayerState() == proto.PLAYER_STATE_DEAD || len(p.GetPos()) == 0 {
+				return true
+			}
+			offset := positionVec(p.GetPos()[0]).sub(at)
+			offset.y = 0
+			distance = min(distance, offset.length())
+			return true
+		})
+
+		switch {
+		case distance > bestDistance:
+			best, bestDistance = []SpawnPoint{point}, distance
+		case distance == bestDistance:
+			best = append(best, point)
+		}
+	}
+	return best[rand.Intn(len(best))]
+}
+
+// spawn brings the player to life at a spawn point with full health and mana, protected from damage for
+// Config.SpawnProtection. Callers must hold r.mu.
+func (r *Room) spawn(p *proto.Player) {
+	point := r.spawnPoint(p)
+	state := proto.PLAYER_STATE_STANDING
+	p.Health = proto2.Float32(100)
+	p.Mana = proto2.Float32(r.config.MaxMana)
+	p.PlayerState = &state
+	p.Pos = []*proto.Player_Position{{X: proto2.Float32(point.X), Y: proto2.Float32(point.Y), Z: proto2.Float32(point.Z)}}
+	p.RespawnTick = nil
+	p.ProtectedTick = proto2.Uint64(r.Tick() + r.ticks(r.config.SpawnProtection))
+}
+
+// kill leaves the player DEAD where they fell until Config.RespawnDelay has passed. Callers must hold r.mu.
+func (r *Room) kill(p *proto.Player) {
+	state := proto.PLAYER_STATE_DEAD
+	p.PlayerState = &state
+	p.ProtectedTick = nil
+	p.RespawnTick = proto2.Uint64(r.Tick() + r.ticks(r.config.RespawnDelay))
+	r.cancelCast(p)
+}
+
+// protected reports whether the player's spawn protection is still on. Callers must hold r.mu.
+func (r *Room) protected(p *proto.Player) bool {
+	return p.ProtectedTick != nil && r.Tick() < p.GetProtectedTick()
+}
+
+// stepRespawns respawns the dead players whose respawn delay is over and ends spawn protections that ran
+// out. It returns the RESPAWN_PLAYER events to broadcast. Callers must hold r.mu.
+func (r *Room) stepRespawns() []roomEvent {
+	var events []roomEvent
+	tick := r.Tick()
+
+	r.players.Range(func(_, value interface{}) bool {
+		p := value.(*proto.Player)
+		if p.ProtectedTick != nil && tick >= p.GetProtectedTick() {
+			p.ProtectedTick = nil
+		}
+		if p.GetPlayerState() != proto.PLAYER_STATE_DEAD || tick < p.GetRespawnTick() {
+			return true
+		}
+		if respawned := r.RespawnPlayer(p); respawned != nil {
+			events = append(events, roomEvent{RESPAWN_PLAYER, respawned})
+		}
+		return true
+	})
+	return events
+}
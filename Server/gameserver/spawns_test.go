@@ -0,0 +1,72 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLoadSpawnPoints(t *testing.T) {
+	points, err := LoadSpawnPoints("../map.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(points, DefaultSpawnPoints()) {
+		t.Fatalf("map.json has spawn points %+v, want the built-in %+v", points, DefaultSpawnPoints())
+	}
+}
+
+func TestRespawn(t *testing.T) {
+	s := New(Config{
+		TickRate:        10,
+		SpawnPoints:     []SpawnPoint{{Name: "West", X: -8, Y: floorY}, {Name: "East", X: 8, Y: floorY}},
+		RespawnDelay:    500 * time.Millisecond,
+		SpawnProtection: 300 * time.Millisecond,
+	})
+	room := s.defaultRoom
+	register := func(name string) *proto.Player {
+		_, _, playerID := registerPlayer(t, s, name)
+		value, _ := room.players.Load(playerID)
+		return value.(*proto.Player)
+	}
+
+	alice := register("Alice")
+	alice.Pos = []*proto.Player_Position{vec3{-6, floorY, 0}.position()}
+	bob := register("Bob")
+	if bob.GetPos()[0].GetX() != 8 {
+		t.Fatalf("Bob spawned at %v, want the spawn point farthest from Alice", bob.GetPos()[0])
+	}
+
+	if damaged, died := room.applyDamage(alice.GetId(), bob, 200); damaged != nil || died || bob.GetHealth() != 100 {
+		t.Fatal("Bob was damaged under spawn protection")
+	}
+	if _, _, err := room.Cast(bob.GetId()); err != nil {
+		t.Fatal(err)
+	}
+	if bob.ProtectedTick != nil {
+		t.Fatal("casting did not end Bob's spawn protection")
+	}
+	if _, died := room.applyDamage(alice.GetId(), bob, 200); !died || bob.GetPlayerState() != proto.PLAYER_STATE_DEAD {
+		t.Fatal("Bob did not die")
+	}
+	if bob.GetRespawnTick() != room.Tick()+5 {
+		t.Fatalf("Bob respawns at tick %d, want %d", bob.GetRespawnTick(), room.Tick()+5)
+	}
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		room.simulate(100*time.Millisecond, now.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	if bob.GetPlayerState() != proto.PLAYER_STATE_DEAD {
+		t.Fatal("Bob respawned before the respawn delay was over")
+	}
+	room.simulate(100*time.Millisecond, now.Add(400*time.Millisecond))
+	if bob.GetPlayerState() != proto.PLAYER_STATE_STANDING || bob.GetHealth() != 100 || bob.RespawnTick != nil {
+		t.Fatalf("Bob did not respawn: %v", bob)
+	}
+	if bob.GetPos()[0].GetX() != 8 || bob.GetProtectedTick() != room.Tick()+3 {
+		t.Fatalf("Bob respawned at %v protected until tick %d, want East until tick %d",
+			bob.GetPos()[0], bob.GetProtectedTick(), room.Tick()+3)
+	}
+}
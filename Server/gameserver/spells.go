@@ -0,0 +1,115 @@
+package gameserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Spell describes a castable spell. Spells are looked up by Player.current_spell.
+type Spell struct {
+	Name   string
+	Damage float32
+	// Speed is the projectile speed in units per second.
+	Speed    float32
+	Radius   float32
+	Lifetime time.Duration
+	// CastTime is how long the caster channels before the projectile is launched, Cooldown how long
+	// after that until the spell can be cast again. ManaCost is taken when the projectile is launched.
+	CastTime time.Duration
+	Cooldown time.Duration
+	ManaCost float32
+}
+
+// DefaultSpells returns the spell table matching the projectile used by the Godot client.
+func DefaultSpells() map[uint32]Spell {
+	return map[uint32]Spell{
+		0: {Name: "Fireball", Damage: 25, Speed: 20, Radius: 0.125, Lifetime: 3 * time.Second,
+			Cooldown: 250 * time.Millisecond, ManaCost: 10},
+	}
+}
+
+// spellEntry is a spell as written in a spell catalog file, see LoadSpells.
+type spellEntry struct {
+	ID       uint32  `json:"id"`
+	Name     string  `json:"name"`
+	Damage   float32 `json:"damage"`
+	Speed    float32 `json:"speed"`
+	Radius   float32 `json:"radius"`
+	Lifetime string  `json:"lifetime"`
+	CastTime string  `json:"cast_time"`
+	Cooldown string  `json:"cooldown"`
+	ManaCost float32 `json:"mana_cost"`
+}
+
+// LoadSpells reads a spell table for Config.Spells from a JSON file holding a "spells" list. Durations
+// are written the way time.ParseDuration reads them, for example "1.5s"; cast_time and cooldown may be
+// left out.
+func LoadSpells(path string) (map[uint32]Spell, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Spells []spellEntry `json:"spells"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(file.Spells) == 0 {
+		return nil, fmt.Errorf("%s: no spells", path)
+	}
+
+	spells := make(map[uint32]Spell, len(file.Spells))
+	for _, entry := range file.Spells {
+		if _, ok := spells[entry.ID]; ok {
+			return nil, fmt.Errorf("%s: spell %d is defined twice", path, entry.ID)
+		}
+		spell, err := entry.spell()
+		if err != nil {
+			return nil, fmt.Errorf("%s: spell %d: %w", path, entry.ID, err)
+		}
+		spells[entry.ID] = spell
+	}
+	return spells, nil
+}
+
+func (e spellEntry) spell() (Spell, error) {
+	spell := Spell{Name: e.Name, Damage: e.Damage, Speed: e.Speed, Radius: e.Radius, ManaCost: e.ManaCost}
+	for _, d := range []struct {
+		name     string
+		value    string
+		duration *time.Duration
+	}{{"lifetime", e.Lifetime, &spell.Lifetime}, {"cast_time", e.CastTime, &spell.CastTime}, {"cooldown", e.Cooldown, &spell.Cooldown}} {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return Spell{}, fmt.Errorf("%s: %w", d.name, err)
+		}
+		if parsed < 0 {
+			return Spell{}, fmt.Errorf("%s is negative", d.name)
+		}
+		*d.duration = parsed
+	}
+	if spell.Speed <= 0 || spell.Radius <= 0 || spell.Lifetime <= 0 {
+		return Spell{}, fmt.Errorf("speed, radius and lifetime must be positive")
+	}
+	if spell.Damage < 0 || spell.ManaCost < 0 {
+		return Spell{}, fmt.Errorf("damage and mana_cost must not be negative")
+	}
+	return spell, nil
+}
+
+// firstSpell returns the lowest spell ID in the table, the spell players start with.
+func firstSpell(spells map[uint32]Spell) uint32 {
+	first, found := uint32(0), false
+	for id := range spells {
+		if !found || id < first {
+			first, found = id, true
+		}
+	}
+	return first
+}
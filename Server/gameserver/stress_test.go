@@ -0,0 +1,138 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// TestConcurrentClients has many clients register, move, switch spells, cast, damage each other, reconnect
+// and disconnect at once while the room simulates. It is meant to be run with -race.
+func TestConcurrentClients(t *testing.T) {
+	spells := DefaultSpells()
+	spells[1] = Spell{Name: "Channeled", Damage: 10, Speed: 10, Radius: 0.2, Lifetime: time.Second, CastTime: 50 * time.Millisecond}
+	s := New(Config{MaxPlayersPerRoom: 64, ResumeGracePeriod: 10 * time.Millisecond, Spells: spells,
+		RespawnDelay: 20 * time.Millisecond, SpawnProtection: time.Millisecond,
+		WarmupTime: 50 * time.Millisecond, ScoreLimit: 5, TimeLimit: 300 * time.Millisecond, PostGameTime: 50 * time.Millisecond,
+		GameMode: TeamDeathmatch})
+	room := s.defaultRoom
+	s.roomsMu.Lock()
+	s.running = true
+	room.start()
+	s.roomsMu.Unlock()
+	defer s.rooms.Range(func(_, value interface{}) bool {
+		value.(*Room).shutdown()
+		<-value.(*Room).done
+		return true
+	})
+
+	clients, rounds, messages := 16, 8, 200
+	if testing.Short() {
+		rounds = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			player := testPlayer("Stress")
+
+			for round := 0; round < rounds; round++ {
+				c, _ := openTestConn(s)
+				s.OnMessage(c, websocket.BinaryMessage, frame(REGISTER, player))
+				playerID, _ := sessionOf(c).get()
+
+				for j := 0; j < messages; j++ {
+					switch rnd.Intn(13) {
+					case 0:
+						moved := proto2.Clone(player).(*proto.Player)
+						moved.Id = proto2.Uint32(playerID)
+						moved.RotationY = proto2.Float32(rnd.Float32() * 6.3)
+						moved.Pos = []*proto.Player_Position{{
+							X: proto2.Float32(rnd.Float32()*18 - 9),
+							Y: proto2.Float32(1),
+							Z: proto2.Float32(rnd.Float32()*18 - 9),
+						}}
+						s.OnMessage(c, websocket.BinaryMessage, frame(UPDATE_LOCATION, moved))
+					case 1:
+						s.OnMessage(c, websocket.BinaryMessage, frame(INPUT, &proto.Input{
+							Sequence:  proto2.Uint32(uint32(j + 1)),
+							MoveX:     proto2.Float32(rnd.Float32()*2 - 1),
+							MoveZ:     proto2.Float32(rnd.Float32()*2 - 1),
+							RotationY: proto2.Float32(rnd.Float32() * 6.3),
+							RotationX: proto2.Float32(0),
+						}))
+					case 2:
+						s.OnMessage(c, websocket.BinaryMessage, []byte{INIT_CAST})
+					case 3:
+						s.OnMessage(c, websocket.BinaryMessage, []byte{REQUEST_SCOREBOARD})
+					case 4:
+						s.OnMessage(c, websocket.BinaryMessage, []byte{REQUEST_PLAYERS})
+					case 5:
+						s.OnMessage(c, websocket.BinaryMessage, frame(SNAPSHOT_ACK, &proto.SnapshotAck{Tick: proto2.Uint64(room.Tick())}))
+					case 6, 7:
+						// DAMAGE_PLAYER frames are ignored, so damage is dealt the way a projectile hit deals it.
+						id, current := sessionOf(c).get()
+						current.mu.Lock()
+						value, ok := current.players.Load(id)
+						if !ok {
+							current.mu.Unlock()
+							continue
+						}
+						damaged, died := current.applyDamage(id, value.(*proto.Player), 40)
+						current.mu.Unlock()
+						if damaged != nil {
+							current.BroadcastMessage(DAMAGE_PLAYER, damaged)
+						}
+						if died {
+							current.BroadcastMessage(REQUEST_SCOREBOARD, current.ReturnScoreboard())
+						}
+					case 8:
+						s.OnMessage(c, websocket.BinaryMessage, frame(CREATE_ROOM, &proto.Room{Name: proto2.String("Stress")}))
+					case 9:
+						var ids []uint32
+						s.rooms.Range(func(key, _ interface{}) bool {
+							ids = append(ids, key.(uint32))
+							return true
+						})
+						s.OnMessage(c, websocket.BinaryMessage, frame(JOIN_ROOM, &proto.Room{Id: proto2.Uint32(ids[rnd.Intn(len(ids))])}))
+					case 10:
+						// The connection drops and the client resumes its player on a new one.
+						sess := sessionOf(c)
+						sess.mu.Lock()
+						token := sess.resumeToken
+						sess.mu.Unlock()
+						s.OnClose(c, nil)
+						c, _ = openTestConn(s)
+						s.OnMessage(c, websocket.BinaryMessage, frame(RESUME, &proto.Resume{Token: proto2.String(token)}))
+					case 11:
+						s.OnMessage(c, websocket.BinaryMessage, frame(SELECT_SPELL, &proto.SpellSelection{Spell: proto2.Uint32(uint32(rnd.Intn(2)))}))
+					case 12:
+						s.OnMessage(c, websocket.BinaryMessage, frame(SWITCH_TEAM, &proto.TeamSwitch{Team: proto2.Uint32(uint32(rnd.Intn(3)))}))
+					}
+				}
+				s.OnClose(c, nil)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	// Disconnected players are removed once their grace period is over.
+	deadline := time.Now().Add(time.Second)
+	s.rooms.Range(func(_, value interface{}) bool {
+		for value.(*Room).PlayerCount() != 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if count := value.(*Room).PlayerCount(); count != 0 {
+			t.Fatalf("%d players left in room %d after every client disconnected", count, value.(*Room).ID())
+		}
+		return true
+	})
+}
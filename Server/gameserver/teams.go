@@ -0,0 +1,161 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"sort"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// GameMode selects the rules of the matches played in every room.
+type GameMode int
+
+const (
+	// FreeForAll lets every player damage every other player.
+	FreeForAll GameMode = iota
+	// TeamDeathmatch splits the players into Config.Teams teams that score together.
+	TeamDeathmatch
+)
+
+// teams reports whether players play in teams.
+func (r *Room) teams() bool {
+	return r.config.GameMode == TeamDeathmatch
+}
+
+// teammates reports whether two different players are on the same team. Callers must hold r.mu.
+func (r *Room) teammates(a, b *proto.Player) bool {
+	return r.teams() && a.GetId() != b.GetId() && a.GetTeam() == b.GetTeam()
+}
+
+// enemies reports whether a and b fight each other: they are different players, not on the same team.
+// Callers must hold r.mu.
+func (r *Room) enemies(a, b *proto.Player) bool {
+	return a.GetId() != b.GetId() && !r.teammates(a, b)
+}
+
+// canDamage reports whether the caster's projectiles can damage the target, which they cannot for
+// teammates unless Config.FriendlyFire is set. Callers must hold r.mu.
+func (r *Room) canDamage(casterID uint32, target *proto.Player) bool {
+	if !r.teams() || r.config.FriendlyFire {
+		return true
+	}
+	value, ok := r.players.Load(casterID)
+	return !ok || !r.teammates(value.(*proto.Player), target)
+}
+
+// teamTotals returns the number of players and the score of every team. Callers must hold r.mu.
+func (r *Room) teamTotals() ([]int, []uint32) {
+	sizes := make([]int, r.config.Teams+1)
+	scores := make([]uint32, r.config.Teams+1)
+	copy(scores, r.teamScore)
+	r.players.Range(func(_, value interface{}) bool {
+		team := value.(*proto.Player).GetTeam()
+		if team == 0 || int(team) > r.config.Teams {
+			return true
+		}
+		sizes[team]++
+		return true
+	})
+	return sizes, scores
+}
+
+// creditTeam scores a kill for the killer's team in team game modes. A team keeps the kills of its
+// players when they switch teams or leave. Callers must hold r.mu.
+func (r *Room) creditTeam(killer *proto.Player) {
+	if team := killer.GetTeam(); r.teams() && team != 0 && int(team) <= r.config.Teams {
+		r.teamScore[team]++
+	}
+}
+
+// assignTeam puts the player on the team with the fewest players, or of those the one with the lowest
+// score. In free for all the player gets no team. Callers must hold r.mu.
+func (r *Room) assignTeam(p *proto.Player) {
+	if !r.teams() {
+		p.Team = nil
+		return
+	}
+	sizes, scores := r.teamTotals()
+	best := uint32(1)
+	for team := uint32(2); int(team) <= r.config.Teams; team++ {
+		if sizes[team] < sizes[best] || (sizes[team] == sizes[best] && scores[team] < scores[best]) {
+			best = team
+		}
+	}
+	p.Team = proto2.Uint32(best)
+}
+
+// SwitchTeam moves the player to another team, returning the marshaled player. Switching costs a life: a
+// living player dies without scoring anyone a kill, a dead one keeps waiting for their respawn, and both
+// respawn on the new team after Config.RespawnDelay. The switch is refused with TEAM_SWITCH_REFUSED
+// outside team game modes, for teams that do not exist, and if the team sizes would end up more than one
+// player apart.
+func (r *Room) SwitchTeam(playerID, team uint32) ([]byte, error) {
+	if !r.teams() {
+		return nil, newError(proto.ErrorCode_TEAM_SWITCH_REFUSED, "the game mode has no teams")
+	}
+	if team == 0 || int(team) > r.config.Teams {
+		return nil, newError(proto.ErrorCode_TEAM_SWITCH_REFUSED, "team %d does not exist", team)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.players.Load(playerID)
+	if !ok {
+		return nil, nil
+	}
+	p := value.(*proto.Player)
+	if p.GetTeam() == team {
+		return nil, nil
+	}
+
+	sizes, _ := r.teamTotals()
+	sizes[p.GetTeam()]--
+	sizes[team]++
+	smallest, largest := sizes[1], sizes[1]
+	for _, size := range sizes[1:] {
+		smallest, largest = min(smallest, size), max(largest, size)
+	}
+	if largest-smallest > 1 {
+		return nil, newError(proto.ErrorCode_TEAM_SWITCH_REFUSED, "team %d has enough players", team)
+	}
+
+	fmt.Printf("Player %d switched from team %d to team %d\n", playerID, p.GetTeam(), team)
+	p.Team = proto2.Uint32(team)
+	if scoreValue, ok := r.scoreboard.Load(playerID); ok {
+		scoreValue.(*proto.Score).Team = proto2.Uint32(team)
+	}
+	if p.GetPlayerState() != proto.PLAYER_STATE_DEAD {
+		p.Health = proto2.Float32(0)
+		r.kill(p)
+	}
+
+	byteSlice, protoErr := r.marshalPlayer(p)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling player with ID %d after switching teams: %v\n", playerID, protoErr)
+		return nil, nil
+	}
+	return byteSlice, nil
+}
+
+// teamScores returns the team totals reported in the scoreboard and match results, highest score first,
+// or nil outside team game modes. Callers must hold r.mu.
+func (r *Room) teamScores() []*proto.TeamScore {
+	if !r.teams() {
+		return nil
+	}
+	sizes, scores := r.teamTotals()
+	teams := make([]*proto.TeamScore, 0, r.config.Teams)
+	for team := 1; team <= r.config.Teams; team++ {
+		teams = append(teams, &proto.TeamScore{
+			Team:    proto2.Uint32(uint32(team)),
+			Score:   proto2.Uint32(scores[team]),
+			Players: proto2.Uint32(uint32(sizes[team])),
+		})
+	}
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].GetScore() > teams[j].GetScore()
+	})
+	return teams
+}
@@ -0,0 +1,83 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"testing"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestTeams(t *testing.T) {
+	s := New(Config{GameMode: TeamDeathmatch, MaxPlayersPerRoom: 8})
+	room := s.defaultRoom
+	register := func(name string) *proto.Player {
+		_, _, playerID := registerPlayer(t, s, name)
+		value, _ := room.players.Load(playerID)
+		return value.(*proto.Player)
+	}
+
+	players := []*proto.Player{register("A"), register("B"), register("C"), register("D")}
+	for i, p := range players {
+		if p.GetTeam() != uint32(i%2+1) {
+			t.Fatalf("player %d joined team %d, want %d", i, p.GetTeam(), i%2+1)
+		}
+	}
+	// The teams are even, so the next player joins the one behind on score.
+	for i := 0; i < 3; i++ {
+		players[1].ProtectedTick = nil
+		if _, died := room.applyDamage(players[0].GetId(), players[1], 200); !died {
+			t.Fatal("enemy did not die")
+		}
+		room.RespawnPlayer(players[1])
+	}
+	if e := register("E"); e.GetTeam() != 2 {
+		t.Fatalf("player joined team %d, want the losing team 2", e.GetTeam())
+	}
+
+	if room.canDamage(players[0].GetId(), players[2]) || !room.canDamage(players[0].GetId(), players[1]) {
+		t.Fatal("friendly fire is off, but teammates could damage each other or enemies could not")
+	}
+	players[2].ProtectedTick = nil
+	if _, died := room.applyDamage(players[0].GetId(), players[2], 200); !died {
+		t.Fatal("teammate did not die")
+	}
+	if value, _ := room.scoreboard.Load(players[0].GetId()); value.(*proto.Score).GetScore() != 3 || room.teamScore[1] != 3 {
+		t.Fatal("a team kill scored")
+	}
+
+	// Team 2 has 3 players, team 1 has 2.
+	if _, err := room.SwitchTeam(players[0].GetId(), 2); err == nil {
+		t.Fatal("switching to the larger team was approved")
+	}
+	switched, err := room.SwitchTeam(players[1].GetId(), 1)
+	if err != nil || switched == nil || players[1].GetTeam() != 1 {
+		t.Fatalf("switching to the smaller team was refused: %v", err)
+	}
+	if players[1].GetPlayerState() != proto.PLAYER_STATE_DEAD || players[1].GetRespawnTick() <= room.Tick() {
+		t.Fatalf("living player switched teams without waiting to respawn: %v", players[1])
+	}
+	if _, err := room.SwitchTeam(players[1].GetId(), 3); err == nil {
+		t.Fatal("switching to a team that does not exist was approved")
+	}
+	// A dead player keeps waiting for their respawn on the new team.
+	respawnTick := players[2].GetRespawnTick()
+	if _, err := room.SwitchTeam(players[2].GetId(), 2); err != nil {
+		t.Fatal(err)
+	}
+	if players[2].GetPlayerState() != proto.PLAYER_STATE_DEAD || players[2].GetRespawnTick() != respawnTick ||
+		players[2].GetHealth() > 0 {
+		t.Fatalf("dead player was respawned by switching teams: %v", players[2])
+	}
+
+	// Team 1 keeps its kills when the player who scored them leaves.
+	room.removePlayer(players[0].GetId())
+	scoreboard := &proto.Scoreboard{}
+	if err := proto2.Unmarshal(room.ReturnScoreboard(), scoreboard); err != nil {
+		t.Fatal(err)
+	}
+	teams := scoreboard.GetTeam()
+	if len(teams) != 2 || teams[0].GetTeam() != 1 || teams[0].GetScore() != 3 || teams[0].GetPlayers() != 1 ||
+		teams[1].GetScore() != 0 || teams[1].GetPlayers() != 3 {
+		t.Fatalf("scoreboard reports teams %v", teams)
+	}
+}
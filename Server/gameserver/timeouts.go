@@ -0,0 +1,136 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+// afkCheck tracks when a player last moved, looked around or cast, see Room.checkTimeouts.
+type afkCheck struct {
+	pos        vec3
+	hasPos     bool
+	rotY, rotX float32
+	casting    bool
+	since      time.Time
+	warned     bool
+}
+
+// update compares the player with the state seen at the previous check and restarts the AFK clock if
+// anything changed.
+func (a *afkCheck) update(p *proto.Player, now time.Time) {
+	pos, hasPos := vec3{}, len(p.GetPos()) > 0
+	if hasPos {
+		pos = positionVec(p.GetPos()[0])
+	}
+	if pos == a.pos && hasPos == a.hasPos && p.GetRotationY() == a.rotY && p.GetRotationX() == a.rotX &&
+		p.GetCasting() == a.casting {
+		return
+	}
+	a.pos, a.hasPos = pos, hasPos
+	a.rotY, a.rotX = p.GetRotationY(), p.GetRotationX()
+	a.casting = p.GetCasting()
+	a.since = now
+	a.warned = false
+}
+
+// timeoutStatus decides what to do about something inactive since the given time: warn once when less
+// than warning is left of timeout, or give up when timeout has passed. It returns the time left too.
+func timeoutStatus(since time.Time, warned *bool, timeout, warning time.Duration, now time.Time) (warn, expired bool, left time.Duration) {
+	left = timeout - now.Sub(since)
+	switch {
+	case left <= 0:
+		return false, true, 0
+	case left <= warning && !*warned:
+		*warned = true
+		return true, false, left
+	}
+	return false, false, left
+}
+
+// checkIdle applies timeoutStatus to the time the last frame or pong arrived.
+func (s *session) checkIdle(now time.Time, timeout, warning time.Duration) (bool, bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return timeoutStatus(s.lastReceived, &s.idleWarned, timeout, warning, now)
+}
+
+// timeoutEvent is a warning or disconnect decided by checkTimeouts.
+type timeoutEvent struct {
+	conn     *websocket.Conn
+	playerID uint32
+	reason   proto.TimeoutReason
+	left     time.Duration
+	expired  bool
+}
+
+// checkTimeouts warns the connections that are about to reach Config.IdleTimeout, and the players about
+// to reach Config.AFKTimeout, with TIMEOUT_WARNING, and closes the connections of the ones that reached it.
+// The idle timeout applies to every connection in the room, registered or not; their players leave
+// through OnClose like any other. Idle players can still be resumed, AFK players cannot.
+func (r *Room) checkTimeouts(now time.Time) {
+	var events []timeoutEvent
+
+	r.mu.Lock()
+	r.sessions.Range(func(key, _ interface{}) bool {
+		sess := key.(*session)
+		conn := sess.out.conn
+		id, _ := sess.get()
+		warn, expired, left := sess.checkIdle(now, r.config.IdleTimeout, r.config.TimeoutWarning)
+		if warn || expired {
+			events = append(events, timeoutEvent{conn, id, proto.TimeoutReason_IDLE, left, expired})
+			if expired {
+				return true
+			}
+		}
+		if id == 0 {
+			return true
+		}
+
+		value, ok := r.players.Load(id)
+		if !ok {
+			return true
+		}
+		check, ok := r.afk[id]
+		if !ok {
+			check = &afkCheck{since: now}
+			r.afk[id] = check
+		}
+		check.update(value.(*proto.Player), now)
+		warn, expired, left = timeoutStatus(check.since, &check.warned, r.config.AFKTimeout, r.config.TimeoutWarning, now)
+		if warn || expired {
+			events = append(events, timeoutEvent{conn, id, proto.TimeoutReason_AFK, left, expired})
+		}
+		return true
+	})
+	r.mu.Unlock()
+
+	for _, event := range events {
+		if event.expired {
+			fmt.Printf("Disconnecting %s of player %d, %v for too long\n", event.conn.RemoteAddr().String(), event.playerID, event.reason)
+			if event.reason == proto.TimeoutReason_AFK {
+				sessionOf(event.conn).kick()
+			}
+			event.conn.Close()
+			continue
+		}
+
+		byteSlice, protoErr := proto2.Marshal(&proto.TimeoutWarning{
+			Reason:      event.reason.Enum(),
+			SecondsLeft: proto2.Uint32(uint32(math.Ceil(event.left.Seconds()))),
+		})
+		if protoErr != nil {
+			fmt.Printf("Error marshaling TimeoutWarning: %v\n", protoErr)
+			continue
+		}
+		err := writeFrame(event.conn, TIMEOUT_WARNING, byteSlice)
+		if err != nil {
+			fmt.Println("TIMEOUT_WARNING error")
+			fmt.Println(err.Error())
+		}
+	}
+}
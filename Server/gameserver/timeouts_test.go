@@ -0,0 +1,99 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestTimeouts(t *testing.T) {
+	s := New(Config{IdleTimeout: 30 * time.Second, AFKTimeout: time.Minute, TimeoutWarning: 10 * time.Second})
+	room := s.defaultRoom
+	c, underlying, playerID := registerPlayer(t, s, "Idle")
+	sess := sessionOf(c)
+	now := time.Now()
+
+	warnings := 0
+	expectWarning := func(reason proto.TimeoutReason, secondsLeft uint32) {
+		t.Helper()
+		waitSent(t, c)
+		var got []*proto.TimeoutWarning
+		for _, message := range underlying.messages(t) {
+			if message[0] == TIMEOUT_WARNING {
+				warning := &proto.TimeoutWarning{}
+				if err := proto2.Unmarshal(message[1:], warning); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, warning)
+			}
+		}
+		if len(got) != warnings+1 || got[warnings].GetReason() != reason || got[warnings].GetSecondsLeft() != secondsLeft {
+			t.Fatalf("got warnings %v, want a new %v warning with %d seconds left", got, reason, secondsLeft)
+		}
+		warnings++
+	}
+
+	room.checkTimeouts(now)
+	room.checkTimeouts(now.Add(25 * time.Second))
+	expectWarning(proto.TimeoutReason_IDLE, 5)
+
+	sess.received(now.Add(50 * time.Second))
+	room.checkTimeouts(now.Add(50 * time.Second))
+	expectWarning(proto.TimeoutReason_AFK, 10)
+
+	// Looking around restarts the AFK clock.
+	room.mu.Lock()
+	value, _ := room.players.Load(playerID)
+	value.(*proto.Player).RotationY = proto2.Float32(1)
+	room.mu.Unlock()
+	sess.received(now.Add(55 * time.Second))
+	room.checkTimeouts(now.Add(55 * time.Second))
+	if sess.wasKicked() {
+		t.Fatal("active player was kicked")
+	}
+
+	sess.received(now.Add(2 * time.Minute))
+	room.checkTimeouts(now.Add(2 * time.Minute))
+	if !sess.wasKicked() {
+		t.Fatal("AFK player was not kicked")
+	}
+	s.OnClose(c, nil)
+	if room.PlayerCount() != 0 {
+		t.Fatal("AFK player was held for resuming")
+	}
+}
+
+func TestHeartbeatsBeforeRegistering(t *testing.T) {
+	s := New(Config{IdleTimeout: 30 * time.Second, TimeoutWarning: 10 * time.Second})
+	room := s.defaultRoom
+	c, underlying := openTestConn(s)
+	now := time.Now()
+	sessionOf(c).received(now)
+
+	room.ping(now)
+	waitSent(t, c)
+	underlying.mu.Lock()
+	written := append([]byte(nil), underlying.written...)
+	underlying.mu.Unlock()
+	// A ping frame: FIN and opcode 9, then an unmasked 8 byte payload holding the send time.
+	if len(written) != 10 || written[0] != 0x89 || written[1] != 8 || binary.BigEndian.Uint64(written[2:]) != uint64(now.UnixNano()) {
+		t.Fatalf("unregistered connection was sent % x, want a ping", written)
+	}
+
+	room.checkTimeouts(now.Add(25 * time.Second))
+	waitSent(t, c)
+	messages := underlying.messages(t)
+	warning := &proto.TimeoutWarning{}
+	if last := messages[len(messages)-1]; last[0] != TIMEOUT_WARNING || proto2.Unmarshal(last[1:], warning) != nil ||
+		warning.GetReason() != proto.TimeoutReason_IDLE {
+		t.Fatalf("unregistered connection was not warned about being idle: %v", messages)
+	}
+
+	room.checkTimeouts(now.Add(31 * time.Second))
+	if !underlying.isClosed() {
+		t.Fatal("idle unregistered connection was left open")
+	}
+}
@@ -0,0 +1,135 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+)
+
+// locationCheck is the last client-reported position the server accepted for a player, and the distance
+// they left unused of their movement budgets since, see movementBudget.
+type locationCheck struct {
+	at         time.Time
+	pos        vec3
+	horizontal float32
+	vertical   float32
+}
+
+func finite(values ...float32) bool {
+	for _, v := range values {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// clampToArena moves pos horizontally back inside the arena. It reports whether pos was outside. pos must
+// be finite: NaN coordinates are kept.
+func clampToArena(pos vec3) (vec3, bool) {
+	clamped := vec3{
+		x: float32(math.Max(-arenaHalfSize, math.Min(arenaHalfSize, float64(pos.x)))),
+		y: pos.y,
+		z: float32(math.Max(-arenaHalfSize, math.Min(arenaHalfSize, float64(pos.z)))),
+	}
+	return clamped, clamped != pos
+}
+
+// resetLocationCheck makes pos the accepted position of a player, e.g. after the server placed them.
+// Callers must hold r.mu.
+func (r *Room) resetLocationCheck(id uint32, pos vec3, now time.Time) {
+	check, ok := r.locations[id]
+	if !ok {
+		check = &locationCheck{}
+		r.locations[id] = check
+	}
+	check.at = now
+	check.pos = pos
+	check.horizontal = r.config.MoveTolerance
+	check.vertical = r.config.MoveTolerance
+}
+
+// heightRange returns the lowest and highest a player's center can be: standing on the floor, and at the
+// top of a jump, both with Config.MoveTolerance of slack.
+func (r *Room) heightRange() (float32, float32) {
+	jump := r.config.JumpVelocity * r.config.JumpVelocity / (2 * r.config.Gravity)
+	return floorY - r.config.MoveTolerance, floorY + jump + r.config.MoveTolerance
+}
+
+// movementBudget returns the distance a player may move at speed in elapsed, on top of what they left
+// unused of their previous budget. At most Config.MoveTolerance is carried over, so the tolerance absorbs
+// jitter once rather than being added to every update.
+func (r *Room) movementBudget(left, speed float32, elapsed time.Duration) float32 {
+	return min(left, r.config.MoveTolerance) + speed*float32(elapsed.Seconds())
+}
+
+// validateLocation checks a client-reported position against the player's last accepted one. It returns
+// the position to store and whether the update was a violation. Non-finite positions, horizontal moves
+// faster than Config.MaxSpeed and vertical ones faster than Config.JumpVelocity keep the last accepted
+// position; see movementBudget for the slack allowed. Positions outside the arena or above and below
+// heightRange are clamped. Callers must hold r.mu.
+func (r *Room) validateLocation(id uint32, pos vec3, now time.Time) (vec3, bool) {
+	check, ok := r.locations[id]
+	if !ok {
+		// Unknown players have nothing to compare against, accept them where they are.
+		r.resetLocationCheck(id, pos, now)
+		check = r.locations[id]
+	}
+
+	if !finite(pos.x, pos.y, pos.z) {
+		return check.pos, true
+	}
+
+	pos, outside := clampToArena(pos)
+	if low, high := r.heightRange(); pos.y < low || pos.y > high {
+		pos.y = max(low, min(high, pos.y))
+		outside = true
+	}
+
+	elapsed := now.Sub(check.at)
+	horizontal := r.movementBudget(check.horizontal, r.config.MaxSpeed, elapsed)
+	vertical := r.movementBudget(check.vertical, r.config.JumpVelocity, elapsed)
+	moved := pos.sub(check.pos)
+	climbed := float32(math.Abs(float64(moved.y)))
+	moved.y = 0
+	if moved.length() > horizontal || climbed > vertical {
+		return check.pos, true
+	}
+
+	check.at = now
+	check.pos = pos
+	check.horizontal = horizontal - moved.length()
+	check.vertical = vertical - climbed
+	return pos, outside
+}
+
+// recordViolation counts a movement violation of the player and reports whether they just reached the
+// kick threshold. Callers must hold r.mu.
+func (r *Room) recordViolation(id uint32) bool {
+	c, ok := r.conns.Load(id)
+	if !ok {
+		return false
+	}
+	violations := sessionOf(c.(*websocket.Conn)).recordMoveViolation()
+	fmt.Printf("Player %d sent an invalid location update or input (%d violations)\n", id, violations)
+	return r.config.MaxMoveViolations > 0 && violations == r.config.MaxMoveViolations
+}
+
+// MoveViolations returns how many invalid location updates and inputs the player has sent, in any room.
+func (s *GameServer) MoveViolations(playerID uint32) int {
+	return s.offensesOf(playerID).moveViolations
+}
+
+// correction returns the POSITION_CORRECTION message that rubber-bands a player back to the server's
+// state. Callers must hold r.mu.
+func (r *Room) correction(p *proto.Player) []byte {
+	byteSlice, protoErr := r.marshalPlayer(p)
+	if protoErr != nil {
+		fmt.Printf("Error marshaling position correction for player with ID %d: %v\n", p.GetId(), protoErr)
+		return nil
+	}
+	return byteSlice
+}
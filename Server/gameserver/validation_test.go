@@ -0,0 +1,145 @@
+package gameserver
+
+import (
+	"Server/proto"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lesismal/nbio/nbhttp/websocket"
+	proto2 "google.golang.org/protobuf/proto"
+)
+
+func TestValidateLocation(t *testing.T) {
+	room := New(Config{}).defaultRoom
+	start := time.Now()
+	frameTime := time.Second / 60
+
+	tests := []struct {
+		name string
+		// step is how far the player moves every frame at 60 Hz.
+		step  vec3
+		cheat bool
+	}{
+		{"walk", vec3{5.0 / 60, 0, 0}, false},
+		{"run at the speed limit", vec3{7.5 / 60, 0, 0}, false},
+		{"speed hack", vec3{1.1, 0, 0}, true},
+		{"slow speed hack", vec3{9.0 / 60, 0, 0}, true},
+		{"fall", vec3{0, -3.0 / 60, 0}, false},
+		{"fly", vec3{0, 10.0 / 60, 0}, true},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := uint32(i + 1)
+			pos := vec3{-8, floorY, 0}
+			if test.step.y < 0 {
+				_, high := room.heightRange()
+				pos.y = high
+			}
+			room.resetLocationCheck(id, pos, start)
+
+			from, accepted := pos, pos
+			violations := 0
+			for frame := 1; frame <= 60; frame++ {
+				pos = pos.add(test.step)
+				var invalid bool
+				accepted, invalid = room.validateLocation(id, pos, start.Add(time.Duration(frame)*frameTime))
+				if invalid {
+					violations++
+				}
+			}
+			if (violations > 0) != test.cheat {
+				t.Fatalf("%d violations in a second", violations)
+			}
+
+			moved := accepted.sub(from)
+			climbed := float32(math.Abs(float64(moved.y)))
+			moved.y = 0
+			if moved.length() > room.config.MaxSpeed+room.config.MoveTolerance ||
+				climbed > room.config.JumpVelocity+room.config.MoveTolerance {
+				t.Fatalf("player got %v u horizontally and %v u vertically in a second", moved.length(), climbed)
+			}
+		})
+	}
+}
+
+func TestValidateLocationJitter(t *testing.T) {
+	room := New(Config{}).defaultRoom
+	start := time.Now()
+	pos := vec3{0, floorY, 0}
+	room.resetLocationCheck(1, pos, start)
+
+	// Four updates of a player walking at 5 u/s arrive at once after a lag spike, then the rest on time.
+	for frame := 1; frame <= 60; frame++ {
+		received := start.Add(time.Duration(max(frame, 4)) * time.Second / 60)
+		pos.x += 5.0 / 60
+		if _, invalid := room.validateLocation(1, pos, received); invalid {
+			t.Fatalf("update %d was rejected", frame)
+		}
+	}
+}
+
+func TestValidateLocationBounds(t *testing.T) {
+	room := New(Config{}).defaultRoom
+	now := time.Now()
+	room.resetLocationCheck(1, vec3{8.5, floorY, 0}, now)
+
+	tests := []struct {
+		name string
+		pos  vec3
+		want vec3
+	}{
+		{"outside the arena", vec3{9.5, floorY, 0}, vec3{9, floorY, 0}},
+		{"below the floor", vec3{9, -5, 0}, vec3{9, floorY, 0}},
+		{"NaN", vec3{float32(math.NaN()), floorY, 0}, vec3{9, floorY, 0}},
+		{"infinite", vec3{9, float32(math.Inf(1)), 0}, vec3{9, floorY, 0}},
+	}
+	for _, test := range tests {
+		now = now.Add(time.Second)
+		got, invalid := room.validateLocation(1, test.pos, now)
+		if !invalid {
+			t.Errorf("%s: %v was accepted", test.name, test.pos)
+		}
+		if got.x != test.want.x || got.z != test.want.z || got.y < test.want.y-room.config.MoveTolerance {
+			t.Errorf("%s: kept %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// Teleporting up is rejected as too fast.
+	now = now.Add(time.Second)
+	if _, invalid := room.validateLocation(1, vec3{9, floorY, 0}, now); invalid {
+		t.Fatal("standing still was rejected")
+	}
+	got, invalid := room.validateLocation(1, vec3{9, 3, 0}, now.Add(10*time.Millisecond))
+	if !invalid || got.y != floorY {
+		t.Fatalf("teleporting up was accepted at %v", got)
+	}
+}
+
+func TestMoveViolationsFollowPlayer(t *testing.T) {
+	s := New(Config{MaxMoveViolations: 3})
+	c, _, playerID := registerPlayer(t, s, "Teleporter")
+	teleport := func() {
+		_, room := sessionOf(c).get()
+		moved := testPlayer("Teleporter")
+		moved.Pos = []*proto.Player_Position{vec3{0, 40, 0}.position()}
+		s.OnMessage(c, websocket.BinaryMessage, frame(UPDATE_LOCATION, moved))
+		if room.PlayerCount() == 0 {
+			t.Fatal("player left the room")
+		}
+	}
+
+	teleport()
+	teleport()
+	s.OnMessage(c, websocket.BinaryMessage, frame(CREATE_ROOM, &proto.Room{Name: proto2.String("Hideout")}))
+	if _, room := sessionOf(c).get(); room == s.defaultRoom {
+		t.Fatal("player did not move to the new room")
+	}
+	if violations := s.MoveViolations(playerID); violations != 2 {
+		t.Fatalf("%d violations after switching rooms, want 2", violations)
+	}
+	teleport()
+	if !sessionOf(c).wasKicked() {
+		t.Fatal("player was not kicked after their third violation")
+	}
+}
@@ -26,6 +26,8 @@ const (
 	PLAYER_STATE_STANDING  PLAYER_STATE = 0
 	PLAYER_STATE_CROUCHING PLAYER_STATE = 1
 	PLAYER_STATE_JUMPING   PLAYER_STATE = 2
+	// Killed and waiting to respawn. Only set by the server.
+	PLAYER_STATE_DEAD PLAYER_STATE = 3
 )
 
 // Enum value maps for PLAYER_STATE.
@@ -34,11 +36,13 @@ var (
 		0: "STANDING",
 		1: "CROUCHING",
 		2: "JUMPING",
+		3: "DEAD",
 	}
 	PLAYER_STATE_value = map[string]int32{
 		"STANDING":  0,
 		"CROUCHING": 1,
 		"JUMPING":   2,
+		"DEAD":      3,
 	}
 )
 
@@ -94,6 +98,23 @@ type Player struct {
 	Casting      *bool              `protobuf:"varint,8,req,name=casting" json:"casting,omitempty"`
 	Pos          []*Player_Position `protobuf:"bytes,9,rep,name=pos" json:"pos,omitempty"`
 	PlayerState  *PLAYER_STATE      `protobuf:"varint,10,req,name=player_state,json=playerState,enum=tutorial.PLAYER_STATE" json:"player_state,omitempty"`
+	// Server tick the state was captured at. Only set by the server.
+	Tick *uint64 `protobuf:"varint,11,opt,name=tick" json:"tick,omitempty"`
+	// Sequence of the last Input the server applied to this player. Only set by the server.
+	LastInput *uint32 `protobuf:"varint,12,opt,name=last_input,json=lastInput" json:"last_input,omitempty"`
+	// Mana left for casting. Only set by the server.
+	Mana *float32 `protobuf:"fixed32,13,opt,name=mana" json:"mana,omitempty"`
+	// Server tick at which current_spell is off cooldown, unset if it never was on cooldown. Only set by
+	// the server.
+	ReadyTick *uint64 `protobuf:"varint,14,opt,name=ready_tick,json=readyTick" json:"ready_tick,omitempty"`
+	// Server tick at which a DEAD player respawns. Only set by the server.
+	RespawnTick *uint64 `protobuf:"varint,15,opt,name=respawn_tick,json=respawnTick" json:"respawn_tick,omitempty"`
+	// Server tick until which the player cannot be damaged after spawning, unset once the protection is
+	// over or the player casts. Only set by the server.
+	ProtectedTick *uint64 `protobuf:"varint,16,opt,name=protected_tick,json=protectedTick" json:"protected_tick,omitempty"`
+	// Team of the player in team game modes, numbered from 1. Unset in free for all. Only set by the
+	// server.
+	Team *uint32 `protobuf:"varint,17,opt,name=team" json:"team,omitempty"`
 }
 
 func (x *Player) Reset() {
@@ -198,14 +219,64 @@ func (x *Player) GetPlayerState() PLAYER_STATE {
 	return PLAYER_STATE_STANDING
 }
 
+func (x *Player) GetTick() uint64 {
+	if x != nil && x.Tick != nil {
+		return *x.Tick
+	}
+	return 0
+}
+
+func (x *Player) GetLastInput() uint32 {
+	if x != nil && x.LastInput != nil {
+		return *x.LastInput
+	}
+	return 0
+}
+
+func (x *Player) GetMana() float32 {
+	if x != nil && x.Mana != nil {
+		return *x.Mana
+	}
+	return 0
+}
+
+func (x *Player) GetReadyTick() uint64 {
+	if x != nil && x.ReadyTick != nil {
+		return *x.ReadyTick
+	}
+	return 0
+}
+
+func (x *Player) GetRespawnTick() uint64 {
+	if x != nil && x.RespawnTick != nil {
+		return *x.RespawnTick
+	}
+	return 0
+}
+
+func (x *Player) GetProtectedTick() uint64 {
+	if x != nil && x.ProtectedTick != nil {
+		return *x.ProtectedTick
+	}
+	return 0
+}
+
+func (x *Player) GetTeam() uint32 {
+	if x != nil && x.Team != nil {
+		return *x.Team
+	}
+	return 0
+}
+
+// Message also used for sending ID of other tasks
 type Damage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	CasterId *uint32  `protobuf:"varint,1,req,name=caster_id,json=casterId" json:"caster_id,omitempty"`
-	TargetId *uint32  `protobuf:"varint,2,req,name=target_id,json=targetId" json:"target_id,omitempty"`
-	Damage   *float32 `protobuf:"fixed32,3,req,name=damage" json:"damage,omitempty"`
+	TargetId *uint32  `protobuf:"varint,2,opt,name=target_id,json=targetId" json:"target_id,omitempty"`
+	Damage   *float32 `protobuf:"fixed32,3,opt,name=damage" json:"damage,omitempty"`
 }
 
 func (x *Damage) Reset() {
@@ -267,6 +338,8 @@ type Players struct {
 	unknownFields protoimpl.UnknownFields
 
 	Player []*Player `protobuf:"bytes,1,rep,name=player" json:"player,omitempty"`
+	// Server tick the snapshot was captured at.
+	Tick *uint64 `protobuf:"varint,2,opt,name=tick" json:"tick,omitempty"`
 }
 
 func (x *Players) Reset() {
@@ -308,6 +381,13 @@ func (x *Players) GetPlayer() []*Player {
 	return nil
 }
 
+func (x *Players) GetTick() uint64 {
+	if x != nil && x.Tick != nil {
+		return *x.Tick
+	}
+	return 0
+}
+
 type Player_Position struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -375,7 +455,7 @@ var File_player_data_proto protoreflect.FileDescriptor
 
 var file_player_data_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x08, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x82, 0x03,
+	0x6f, 0x74, 0x6f, 0x12, 0x08, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x22, 0xc6, 0x04,
 	0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02,
 	0x69, 0x64, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c,
@@ -396,24 +476,38 @@ var file_player_data_proto_rawDesc = []byte{
 	0x73, 0x12, 0x39, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74,
 	0x65, 0x18, 0x0a, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x69,
 	0x61, 0x6c, 0x2e, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x52,
-	0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x34, 0x0a, 0x08,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
-	0x02, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x02, 0x28,
-	0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x02, 0x28, 0x02, 0x52,
-	0x01, 0x7a, 0x22, 0x5a, 0x0a, 0x06, 0x44, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0d, 0x52,
-	0x08, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65,
-	0x18, 0x03, 0x20, 0x02, 0x28, 0x02, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x33,
-	0x0a, 0x07, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x75, 0x74, 0x6f,
-	0x72, 0x69, 0x61, 0x6c, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x2a, 0x38, 0x0a, 0x0c, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x53, 0x54,
-	0x41, 0x54, 0x45, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
-	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x52, 0x4f, 0x55, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x01,
-	0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x55, 0x4d, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x42, 0x09, 0x5a,
-	0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x63, 0x6b, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x61, 0x6e, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x6d,
+	0x61, 0x6e, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x74, 0x69, 0x63,
+	0x6b, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x79, 0x54, 0x69,
+	0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x61, 0x77,
+	0x6e, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70,
+	0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x61, 0x6d, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d,
+	0x1a, 0x34, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01,
+	0x78, 0x18, 0x01, 0x20, 0x02, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18,
+	0x02, 0x20, 0x02, 0x28, 0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20,
+	0x02, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x22, 0x5a, 0x0a, 0x06, 0x44, 0x61, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x02, 0x28, 0x0d, 0x52, 0x08, 0x63, 0x61, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61,
+	0x67, 0x65, 0x22, 0x47, 0x0a, 0x07, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a,
+	0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x74, 0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52,
+	0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x2a, 0x42, 0x0a, 0x0c, 0x50,
+	0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x12, 0x0c, 0x0a, 0x08, 0x53,
+	0x54, 0x41, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x52, 0x4f,
+	0x55, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x55, 0x4d, 0x50,
+	0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x45, 0x41, 0x44, 0x10, 0x03, 0x42,
+	0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -28,6 +28,10 @@ type Score struct {
 	Name  *string `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
 	Id    *uint32 `protobuf:"varint,2,req,name=id" json:"id,omitempty"`
 	Score *uint32 `protobuf:"varint,3,req,name=score" json:"score,omitempty"`
+	// Smoothed round trip time of the player's connection in milliseconds, unset until it is measured.
+	RttMs *uint32 `protobuf:"varint,4,opt,name=rtt_ms,json=rttMs" json:"rtt_ms,omitempty"`
+	// Team of the player in team game modes, unset in free for all.
+	Team *uint32 `protobuf:"varint,5,opt,name=team" json:"team,omitempty"`
 }
 
 func (x *Score) Reset() {
@@ -83,18 +87,100 @@ func (x *Score) GetScore() uint32 {
 	return 0
 }
 
+func (x *Score) GetRttMs() uint32 {
+	if x != nil && x.RttMs != nil {
+		return *x.RttMs
+	}
+	return 0
+}
+
+func (x *Score) GetTeam() uint32 {
+	if x != nil && x.Team != nil {
+		return *x.Team
+	}
+	return 0
+}
+
+// The total score of a team in team game modes.
+type TeamScore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Team    *uint32 `protobuf:"varint,1,req,name=team" json:"team,omitempty"`
+	Score   *uint32 `protobuf:"varint,2,req,name=score" json:"score,omitempty"`
+	Players *uint32 `protobuf:"varint,3,opt,name=players" json:"players,omitempty"`
+}
+
+func (x *TeamScore) Reset() {
+	*x = TeamScore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scoreboard_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TeamScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamScore) ProtoMessage() {}
+
+func (x *TeamScore) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamScore.ProtoReflect.Descriptor instead.
+func (*TeamScore) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TeamScore) GetTeam() uint32 {
+	if x != nil && x.Team != nil {
+		return *x.Team
+	}
+	return 0
+}
+
+func (x *TeamScore) GetScore() uint32 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *TeamScore) GetPlayers() uint32 {
+	if x != nil && x.Players != nil {
+		return *x.Players
+	}
+	return 0
+}
+
 type Scoreboard struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Score []*Score `protobuf:"bytes,1,rep,name=score" json:"score,omitempty"`
+	// Server tick the scoreboard was captured at.
+	Tick *uint64 `protobuf:"varint,2,opt,name=tick" json:"tick,omitempty"`
+	// Team totals, only in team game modes.
+	Team []*TeamScore `protobuf:"bytes,3,rep,name=team" json:"team,omitempty"`
 }
 
 func (x *Scoreboard) Reset() {
 	*x = Scoreboard{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scoreboard_proto_msgTypes[1]
+		mi := &file_scoreboard_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -107,7 +193,7 @@ func (x *Scoreboard) String() string {
 func (*Scoreboard) ProtoMessage() {}
 
 func (x *Scoreboard) ProtoReflect() protoreflect.Message {
-	mi := &file_scoreboard_proto_msgTypes[1]
+	mi := &file_scoreboard_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -120,7 +206,7 @@ func (x *Scoreboard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Scoreboard.ProtoReflect.Descriptor instead.
 func (*Scoreboard) Descriptor() ([]byte, []int) {
-	return file_scoreboard_proto_rawDescGZIP(), []int{1}
+	return file_scoreboard_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Scoreboard) GetScore() []*Score {
@@ -130,19 +216,45 @@ func (x *Scoreboard) GetScore() []*Score {
 	return nil
 }
 
+func (x *Scoreboard) GetTick() uint64 {
+	if x != nil && x.Tick != nil {
+		return *x.Tick
+	}
+	return 0
+}
+
+func (x *Scoreboard) GetTeam() []*TeamScore {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
 var File_scoreboard_proto protoreflect.FileDescriptor
 
 var file_scoreboard_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x08, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x41, 0x0a, 0x05,
+	0x74, 0x6f, 0x12, 0x08, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x6c, 0x0a, 0x05,
 	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
 	0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
 	0x02, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x72, 0x65, 0x18, 0x03, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22,
-	0x33, 0x0a, 0x0a, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x25, 0x0a,
-	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74,
-	0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x05, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x15, 0x0a, 0x06, 0x72, 0x74, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x72, 0x74, 0x74, 0x4d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x22, 0x4f, 0x0a, 0x09, 0x54, 0x65,
+	0x61, 0x6d, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18,
+	0x01, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0x70, 0x0a, 0x0a, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x25, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x75, 0x74, 0x6f, 0x72,
+	0x69, 0x61, 0x6c, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x74, 0x69, 0x63, 0x6b, 0x12, 0x27, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x2e, 0x54, 0x65,
+	0x61, 0x6d, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x42, 0x09, 0x5a,
+	0x07, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -157,18 +269,20 @@ func file_scoreboard_proto_rawDescGZIP() []byte {
 	return file_scoreboard_proto_rawDescData
 }
 
-var file_scoreboard_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_scoreboard_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_scoreboard_proto_goTypes = []interface{}{
 	(*Score)(nil),      // 0: tutorial.Score
-	(*Scoreboard)(nil), // 1: tutorial.Scoreboard
+	(*TeamScore)(nil),  // 1: tutorial.TeamScore
+	(*Scoreboard)(nil), // 2: tutorial.Scoreboard
 }
 var file_scoreboard_proto_depIdxs = []int32{
 	0, // 0: tutorial.Scoreboard.score:type_name -> tutorial.Score
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 1: tutorial.Scoreboard.team:type_name -> tutorial.TeamScore
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_scoreboard_proto_init() }
@@ -190,6 +304,18 @@ func file_scoreboard_proto_init() {
 			}
 		}
 		file_scoreboard_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TeamScore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scoreboard_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Scoreboard); i {
 			case 0:
 				return &v.state
@@ -208,7 +334,7 @@ func file_scoreboard_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_scoreboard_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},